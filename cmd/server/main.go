@@ -6,17 +6,55 @@ import (
 
 	"reto-pokemon-api/internal/application"
 	delivery "reto-pokemon-api/internal/delivery/http"
+	"reto-pokemon-api/internal/domain"
 	"reto-pokemon-api/internal/infrastructure"
 )
 
 func main() {
-	pokeAPIRepo := infrastructure.NewPokeAPIRepository()
+	var pokeAPIRepo domain.PokeAPIRepository
+	if snapshot := os.Getenv("POKEAPI_SNAPSHOT"); snapshot != "" {
+		snapshotRepo, err := infrastructure.NewSnapshotRepository(snapshot)
+		if err != nil {
+			log.Fatalf("Failed to load PokeAPI snapshot: %v", err)
+		}
+		pokeAPIRepo = snapshotRepo
+	} else if store := os.Getenv("POKEAPI_STORE"); store != "" {
+		storeRepo, err := infrastructure.NewStoreRepository(store, 0)
+		if err != nil {
+			log.Fatalf("Failed to open PokeAPI store: %v", err)
+		}
+		if err := infrastructure.WarmCache(storeRepo, store); err != nil {
+			log.Printf("Starting with a cold cache: %v", err)
+		}
+		pokeAPIRepo = storeRepo
+	} else {
+		repo, err := infrastructure.NewPokeAPIRepository()
+		if err != nil {
+			log.Fatalf("Failed to initialize PokeAPI repository: %v", err)
+		}
+		pokeAPIRepo = repo
+	}
+	teamRepo, err := infrastructure.NewTeamRepositoryFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to open team store: %v", err)
+	}
 
 	pokemonUseCase := application.NewPokemonUseCase(pokeAPIRepo)
+	teamUseCase := application.NewTeamUseCase(teamRepo, pokeAPIRepo)
+
+	baseURL, err := delivery.ParseBaseURL(os.Getenv("PUBLIC_BASE_URL"))
+	if err != nil {
+		log.Fatalf("Invalid PUBLIC_BASE_URL: %v", err)
+	}
 
-	pokemonHandler := delivery.NewPokemonHandler(pokemonUseCase)
+	maxAge, listMaxAge := infrastructure.CacheMaxAges()
+	pokemonHandler := delivery.NewPokemonHandler(pokemonUseCase, delivery.CachePolicy{
+		MaxAge:     maxAge,
+		ListMaxAge: listMaxAge,
+	}, baseURL)
+	teamHandler := delivery.NewTeamHandler(teamUseCase)
 
-	router := delivery.SetupRoutes(pokemonHandler)
+	router := delivery.SetupRoutes(pokemonHandler, teamHandler)
 
 	port := os.Getenv("PORT")
 	if port == "" {
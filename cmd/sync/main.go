@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+	"reto-pokemon-api/internal/infrastructure"
+)
+
+// sync descarga el listado completo de pokémon, sus especies y los tipos a un
+// almacén local con el formato de api-data. Lo ya descargado se lee del
+// almacén, así que si se interrumpe basta con volver a ejecutarlo.
+func main() {
+	defaultOut := os.Getenv("POKEAPI_STORE")
+	if defaultOut == "" {
+		defaultOut = "pokeapi-store"
+	}
+
+	out := flag.String("out", defaultOut, "directorio del almacén local")
+	rate := flag.Float64("rate", 5, "peticiones por segundo a PokeAPI (0 = sin límite)")
+	pageSize := flag.Int("page", 50, "pokémon por página del listado")
+	flag.Parse()
+
+	repo, err := infrastructure.NewStoreRepository(*out, *rate)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+
+	start := time.Now()
+
+	// Los tipos se piden por ID para que queden en disco aunque el almacén
+	// todavía no tenga el índice de tipos.
+	for id := 1; id <= len(domain.PokemonTypes); id++ {
+		if _, err := repo.GetTypeByName(strconv.Itoa(id)); err != nil {
+			log.Fatalf("Failed to sync type %d: %v (re-run to resume)", id, err)
+		}
+	}
+	log.Printf("Synced %d types", len(domain.PokemonTypes))
+
+	species := make(map[int]bool)
+	count := 0
+	for offset := 0; ; {
+		list, err := repo.GetPokemonAll(domain.PokemonFilter{Offset: offset, Limit: *pageSize})
+		if err != nil {
+			log.Fatalf("Failed to sync pokemon at offset %d: %v (re-run to resume)", offset, err)
+		}
+		count = list.Count
+
+		for _, p := range *list.Pokemons {
+			speciesID, ok := domain.ResourceID(p.Species.URL)
+			if !ok || species[speciesID] {
+				continue
+			}
+			if _, err := repo.GetPokemonSpecies(speciesID); err != nil {
+				log.Fatalf("Failed to sync species %d: %v (re-run to resume)", speciesID, err)
+			}
+			species[speciesID] = true
+		}
+
+		offset += len(*list.Pokemons)
+		log.Printf("Synced %d/%d pokemon", offset, count)
+		if list.Next == "" || len(*list.Pokemons) == 0 {
+			break
+		}
+	}
+
+	// Una única página con todos los resultados deja guardado el índice
+	// completo. Basta el listado resumido, que no resuelve cada pokémon.
+	if _, err := repo.GetPokemonSummaries(domain.PokemonFilter{Limit: count}); err != nil {
+		log.Fatalf("Failed to sync pokemon index: %v (re-run to resume)", err)
+	}
+
+	log.Printf("Sync completed: %d pokemon, %d species and %d types in %v",
+		count, len(species), len(domain.PokemonTypes), time.Since(start))
+}
@@ -1,11 +1,17 @@
 module reto-pokemon-api
 
-go 1.21
+// Go 1.22.2 lo exige github.com/HugoSmits86/nativewebp, el codificador WebP de
+// los sprites; no tiene versiones que admitan Go 1.21.
+go 1.22.2
 
 require (
+	github.com/HugoSmits86/nativewebp v0.9.3
+	github.com/andybalholm/brotli v1.1.1
 	github.com/gin-gonic/gin v1.9.1
 	github.com/go-playground/validator/v10 v10.17.0
+	github.com/klauspost/compress v1.17.11
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/image v0.24.0
 )
 
 require (
@@ -32,7 +38,7 @@ require (
 	golang.org/x/crypto v0.14.0 // indirect
 	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/sys v0.15.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
+	golang.org/x/text v0.22.0 // indirect
 	google.golang.org/protobuf v1.30.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"sync"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+// localizeWorkers limita las peticiones simultáneas a PokeAPI al traducir
+// listas largas, como el learnset de un pokémon.
+const localizeWorkers = 8
+
+// names devuelve los nombres traducidos de un recurso de PokeAPI. Como el
+// resto de textos localizados son complementarios, un fallo no es un error.
+func (uc *pokemonUseCase) names(resource, name string) domain.LocalizedStrings {
+	names, err := uc.pokeAPIRepo.GetResourceNames(resource, name)
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+// translate traduce cada clave distinta una sola vez con lookup, en paralelo,
+// y devuelve el texto en lang por clave. El idioma en que se encontró cada
+// texto se añade a served.
+func translate(keys []string, lang string, served *domain.ServedLanguages, lookup func(key string) domain.LocalizedStrings) map[string]string {
+	unique := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+
+	var mu sync.Mutex
+	result := make(map[string]string, len(unique))
+	sem := make(chan struct{}, localizeWorkers)
+	var wg sync.WaitGroup
+	for _, key := range unique {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			text, textLang := lookup(key).Lookup(lang)
+			served.Add(textLang)
+			mu.Lock()
+			result[key] = text
+			mu.Unlock()
+		}(key)
+	}
+	wg.Wait()
+	return result
+}
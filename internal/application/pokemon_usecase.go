@@ -1,7 +1,10 @@
 package application
 
 import (
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"reto-pokemon-api/internal/domain"
@@ -44,18 +47,522 @@ func (uc *pokemonUseCase) GetPokemonByID(id string) (*domain.Pokemon, error) {
 		Abilities:  apiPokemon.Abilities,
 		Sprites:    apiPokemon.Sprites,
 		Stats:      apiPokemon.Stats,
+		HeldItems:  apiPokemon.HeldItems,
+		Species:    apiPokemon.Species,
+		Forms:      apiPokemon.Forms,
+		Varieties:  uc.varieties(apiPokemon),
 		IsFavorite: false,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		CreatedAt:  apiPokemon.CreatedAt,
+		UpdatedAt:  apiPokemon.UpdatedAt,
 		PokeAPIID:  apiPokemon.PokeAPIID,
 	}
 	return pokemon, nil
 }
 
 func (uc *pokemonUseCase) GetPokemonByName(name string) (*domain.Pokemon, error) {
-	return uc.pokeAPIRepo.GetPokemonByName(name)
+	apiPokemon, err := uc.pokeAPIRepo.GetPokemonByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.withVarieties(apiPokemon), nil
+}
+
+// withVarieties completa el Pokémon del repositorio como en su detalle. Se
+// copia para no modificar el Pokémon guardado en la caché del repositorio.
+func (uc *pokemonUseCase) withVarieties(apiPokemon *domain.Pokemon) *domain.Pokemon {
+	pokemon := *apiPokemon
+	pokemon.Varieties = uc.varieties(apiPokemon)
+	return &pokemon
+}
+
+// varieties devuelve las variedades de la especie del Pokémon. Es
+// información complementaria, así que un fallo no invalida la respuesta.
+func (uc *pokemonUseCase) varieties(pokemon *domain.Pokemon) []domain.Variety {
+	speciesID, ok := domain.ResourceID(pokemon.Species.URL)
+	if !ok {
+		return nil
+	}
+
+	species, err := uc.pokeAPIRepo.GetPokemonSpecies(speciesID)
+	if err != nil {
+		return nil
+	}
+	return species.Varieties
 }
 
 func (uc *pokemonUseCase) GetPokemonAll(filter domain.PokemonFilter) (*domain.PokemonList, error) {
+	if filter.Ability != "" {
+		ability, err := uc.pokeAPIRepo.GetAbilityByName(strings.ToLower(filter.Ability))
+		if err != nil {
+			return nil, err
+		}
+
+		refs := make([]string, len(ability.Pokemon))
+		for i, p := range ability.Pokemon {
+			refs[i] = p.Name
+		}
+		return uc.resolvePokemonPage(refs, filter)
+	}
+
 	return uc.pokeAPIRepo.GetPokemonAll(filter)
 }
+
+// resolvePokemonPage pagina una lista de IDs o nombres con la misma semántica
+// de limit/offset que el listado de PokeAPI y resuelve solo la página pedida.
+func (uc *pokemonUseCase) resolvePokemonPage(refs []string, filter domain.PokemonFilter) (*domain.PokemonList, error) {
+	start, end := pageBounds(len(refs), filter)
+	pokemons := make([]domain.Pokemon, 0, end-start)
+	for _, ref := range refs[start:end] {
+		pokemon, err := uc.resolvePokemon(ref)
+		if err != nil {
+			return nil, err
+		}
+		pokemons = append(pokemons, *pokemon)
+	}
+
+	return &domain.PokemonList{
+		Count:    len(refs),
+		Pokemons: &pokemons,
+	}, nil
+}
+
+// GetPokemonSummaries es el listado sin el detalle de cada pokémon: con
+// filtro de habilidad basta la propia habilidad, que ya trae nombre y URL.
+func (uc *pokemonUseCase) GetPokemonSummaries(filter domain.PokemonFilter) (*domain.PokemonSummaryList, error) {
+	if filter.Ability == "" {
+		return uc.pokeAPIRepo.GetPokemonSummaries(filter)
+	}
+
+	ability, err := uc.pokeAPIRepo.GetAbilityByName(strings.ToLower(filter.Ability))
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := pageBounds(len(ability.Pokemon), filter)
+	summaries := make([]domain.PokemonSummary, 0, end-start)
+	for _, p := range ability.Pokemon[start:end] {
+		id, _ := domain.ResourceID(p.URL)
+		summaries = append(summaries, domain.PokemonSummary{ID: id, Name: p.Name})
+	}
+
+	return &domain.PokemonSummaryList{
+		Count:    len(ability.Pokemon),
+		Pokemons: summaries,
+	}, nil
+}
+
+// pageBounds traduce limit/offset a los límites de la página dentro de total
+// elementos, con los mismos valores por defecto que PokeAPI.
+func pageBounds(total int, filter domain.PokemonFilter) (start, end int) {
+	offset := 0
+	limit := 20
+	if filter.Offset > 0 {
+		offset = filter.Offset
+	}
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	start = offset
+	if start > total {
+		start = total
+	}
+	end = start + limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+func (uc *pokemonUseCase) GetPokemonStats(id string, req domain.StatsRequest, lang string) (*domain.ComputedStats, error) {
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, domain.ErrInvalidPokemonData
+	}
+
+	pokemon, err := uc.pokeAPIRepo.GetPokemonByID(i)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := domain.ComputeStats(pokemon, req)
+	if err != nil {
+		return nil, err
+	}
+	stats.UpdatedAt = pokemon.UpdatedAt
+	if lang == "" {
+		return stats, nil
+	}
+
+	served := domain.NewServedLanguages(lang)
+	stats.StatNames = uc.statNames(lang, served)
+	natureName, natureLang := uc.names("nature", stats.Nature.Name).Lookup(lang)
+	stats.Nature.LocalizedName = natureName
+	served.Add(natureLang)
+	stats.Language = served.String()
+	return stats, nil
+}
+
+func (uc *pokemonUseCase) statNames(lang string, served *domain.ServedLanguages) map[string]string {
+	return translate(domain.StatNames, lang, served, func(stat string) domain.LocalizedStrings {
+		return uc.names("stat", stat)
+	})
+}
+
+// ComparePokemon resuelve todos los Pokémon en paralelo igual que su detalle
+// (con variedades y, si lang no está vacío, localizados); acepta tanto IDs
+// como nombres. Pedir dos veces el mismo Pokémon, aunque sea una vez por ID y
+// otra por nombre, es un error.
+func (uc *pokemonUseCase) ComparePokemon(ids []string, lang string) (*domain.PokemonComparison, error) {
+	if len(ids) < domain.MinComparePokemon || len(ids) > domain.MaxComparePokemon {
+		return nil, domain.ErrInvalidPokemonData
+	}
+
+	pokemons := make([]*domain.Pokemon, len(ids))
+	errs := make([]error, len(ids))
+
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			pokemon, err := uc.resolvePokemon(id)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			pokemons[i] = uc.withVarieties(pokemon)
+			if lang != "" {
+				pokemons[i] = uc.LocalizePokemon(pokemons[i], lang)
+			}
+		}(i, id)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[int]bool, len(pokemons))
+	for _, pokemon := range pokemons {
+		if seen[pokemon.ID] {
+			return nil, domain.ErrInvalidPokemonData
+		}
+		seen[pokemon.ID] = true
+	}
+
+	comparison := domain.ComparePokemon(pokemons)
+	for _, pokemon := range pokemons {
+		if pokemon.UpdatedAt.After(comparison.UpdatedAt) {
+			comparison.UpdatedAt = pokemon.UpdatedAt
+		}
+	}
+	if lang != "" {
+		served := domain.NewServedLanguages(lang)
+		for _, pokemon := range pokemons {
+			served.Add(pokemon.Language)
+		}
+		statNames := uc.statNames(lang, served)
+		for i, field := range comparison.Fields {
+			comparison.Fields[i].LocalizedField = statNames[field.Field]
+		}
+		comparison.Language = served.String()
+	}
+	return comparison, nil
+}
+
+func (uc *pokemonUseCase) resolvePokemon(idOrName string) (*domain.Pokemon, error) {
+	if i, err := strconv.Atoi(idOrName); err == nil {
+		return uc.pokeAPIRepo.GetPokemonByID(i)
+	}
+	return uc.pokeAPIRepo.GetPokemonByName(idOrName)
+}
+
+func (uc *pokemonUseCase) GetPokemonMoves(id string, filter domain.MoveFilter, lang string) (*domain.PokemonMoveList, error) {
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, domain.ErrInvalidPokemonData
+	}
+
+	moves, err := uc.pokeAPIRepo.GetPokemonMoves(i)
+	if err != nil {
+		return nil, err
+	}
+	// El learnset llega en la misma respuesta que el pokémon, que ya está en
+	// caché, así que su obtención es también la de los movimientos.
+	var updatedAt time.Time
+	if pokemon, err := uc.pokeAPIRepo.GetPokemonByID(i); err == nil {
+		updatedAt = pokemon.UpdatedAt
+	}
+
+	filtered := domain.FilterMoves(moves, filter)
+	served := domain.NewServedLanguages(lang)
+	if lang != "" {
+		keys := make([]string, len(filtered))
+		for j, m := range filtered {
+			keys[j] = m.Move.Name
+		}
+		// Se usa el movimiento completo, que el análisis de equipos ya cachea.
+		names := translate(keys, lang, served, func(name string) domain.LocalizedStrings {
+			if move, err := uc.pokeAPIRepo.GetMoveByName(name); err == nil {
+				return move.Names
+			}
+			return nil
+		})
+		for j := range filtered {
+			filtered[j].Move.LocalizedName = names[filtered[j].Move.Name]
+		}
+	}
+
+	return &domain.PokemonMoveList{
+		PokemonID: i,
+		Count:     len(filtered),
+		Moves:     filtered,
+		Language:  served.String(),
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+// GetMove, GetAbility y GetItem devuelven una copia sin los textos en todos
+// los idiomas, que el repositorio guarda solo para localizar: con lang se
+// añaden el nombre y la descripción en el idioma servido.
+func (uc *pokemonUseCase) GetMove(name, lang string) (*domain.Move, error) {
+	move, err := uc.pokeAPIRepo.GetMoveByName(strings.ToLower(name))
+	if err != nil {
+		return nil, err
+	}
+
+	localized := *move
+	localized.Names, localized.FlavorTexts = nil, nil
+	if lang != "" {
+		localized.LocalizedName, localized.Language = move.Names.Lookup(lang)
+		localized.FlavorText = move.FlavorTexts.Get(lang)
+	}
+	return &localized, nil
+}
+
+func (uc *pokemonUseCase) GetAbility(name, lang string) (*domain.AbilityDetail, error) {
+	ability, err := uc.pokeAPIRepo.GetAbilityByName(strings.ToLower(name))
+	if err != nil {
+		return nil, err
+	}
+
+	localized := *ability
+	localized.Names, localized.FlavorTexts = nil, nil
+	if lang != "" {
+		localized.LocalizedName, localized.Language = ability.Names.Lookup(lang)
+		localized.FlavorText = ability.FlavorTexts.Get(lang)
+	}
+	return &localized, nil
+}
+
+func (uc *pokemonUseCase) GetItem(name, lang string) (*domain.Item, error) {
+	item, err := uc.pokeAPIRepo.GetItemByName(strings.ToLower(name))
+	if err != nil {
+		return nil, err
+	}
+
+	localized := *item
+	localized.Names, localized.FlavorTexts = nil, nil
+	if lang != "" {
+		localized.LocalizedName, localized.Language = item.Names.Lookup(lang)
+		localized.FlavorText = item.FlavorTexts.Get(lang)
+	}
+	return &localized, nil
+}
+
+// GetBerry toma el nombre traducido del objeto de la baya, porque el recurso
+// berry de PokeAPI no tiene names.
+func (uc *pokemonUseCase) GetBerry(name, lang string) (*domain.Berry, error) {
+	berry, err := uc.pokeAPIRepo.GetBerryByName(strings.ToLower(name))
+	if err != nil || lang == "" {
+		return berry, err
+	}
+
+	localized := *berry
+	if item, err := uc.pokeAPIRepo.GetItemByName(berry.Item); err == nil {
+		localized.LocalizedName, localized.Language = item.Names.Lookup(lang)
+	}
+	return &localized, nil
+}
+
+func (uc *pokemonUseCase) GetPokemonEncounters(id string, filter domain.EncounterFilter, lang string) (*domain.PokemonEncounterList, error) {
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, domain.ErrInvalidPokemonData
+	}
+
+	encounters, updatedAt, err := uc.pokeAPIRepo.GetPokemonEncounters(i)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := domain.FilterEncounters(encounters, filter)
+	served := domain.NewServedLanguages(lang)
+	if lang != "" {
+		locations := make([]string, len(filtered))
+		versions := make([]string, len(filtered))
+		for j, e := range filtered {
+			locations[j], versions[j] = e.Location, e.Version
+		}
+		locationNames := translate(locations, lang, served, func(name string) domain.LocalizedStrings {
+			return uc.names("location-area", name)
+		})
+		versionNames := translate(versions, lang, served, func(name string) domain.LocalizedStrings {
+			return uc.names("version", name)
+		})
+		for j := range filtered {
+			filtered[j].LocalizedLocation = locationNames[filtered[j].Location]
+			filtered[j].LocalizedVersion = versionNames[filtered[j].Version]
+		}
+	}
+
+	return &domain.PokemonEncounterList{
+		PokemonID:  i,
+		Count:      len(filtered),
+		Encounters: filtered,
+		Language:   served.String(),
+		UpdatedAt:  updatedAt,
+	}, nil
+}
+
+func (uc *pokemonUseCase) GetPokemonByGeneration(id string, filter domain.PokemonFilter) (*domain.PokemonList, error) {
+	generation, err := uc.pokeAPIRepo.GetGeneration(strings.ToLower(id))
+	if err != nil {
+		return nil, err
+	}
+
+	// PokeAPI no garantiza el orden de las especies de una generación.
+	ids := domain.SpeciesIDs(generation.Species)
+	sort.Ints(ids)
+	return uc.resolvePokemonPage(idRefs(ids), filter)
+}
+
+func (uc *pokemonUseCase) GetPokemonByPokedex(name string, filter domain.PokemonFilter) (*domain.PokemonList, error) {
+	pokedex, err := uc.pokeAPIRepo.GetPokedex(strings.ToLower(name))
+	if err != nil {
+		return nil, err
+	}
+
+	species := make([]domain.SpeciesRef, len(pokedex.Entries))
+	for i, e := range pokedex.Entries {
+		species[i] = e.Species
+	}
+	return uc.resolvePokemonPage(idRefs(domain.SpeciesIDs(species)), filter)
+}
+
+func idRefs(ids []int) []string {
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		refs[i] = strconv.Itoa(id)
+	}
+	return refs
+}
+
+func (uc *pokemonUseCase) GetPokemonForms(id, lang string) (*domain.PokemonVarietyList, error) {
+	pokemon, err := uc.resolvePokemon(strings.ToLower(id))
+	if err != nil {
+		return nil, err
+	}
+
+	speciesID, ok := domain.ResourceID(pokemon.Species.URL)
+	if !ok {
+		return nil, domain.ErrInvalidPokemonData
+	}
+
+	species, err := uc.pokeAPIRepo.GetPokemonSpecies(speciesID)
+	if err != nil {
+		return nil, err
+	}
+
+	varieties := make([]domain.PokemonVariety, 0, len(species.Varieties))
+	var updatedAt time.Time
+	for _, v := range species.Varieties {
+		variety, err := uc.pokeAPIRepo.GetPokemonByName(v.Name)
+		if err != nil {
+			return nil, err
+		}
+		if variety.UpdatedAt.After(updatedAt) {
+			updatedAt = variety.UpdatedAt
+		}
+		varieties = append(varieties, domain.PokemonVariety{
+			ID:        variety.ID,
+			Name:      variety.Name,
+			IsDefault: v.IsDefault,
+			Types:     variety.Types,
+			Stats:     variety.Stats,
+			Sprites:   variety.Sprites,
+		})
+	}
+
+	list := &domain.PokemonVarietyList{
+		SpeciesID: species.ID,
+		Species:   species.Name,
+		Count:     len(varieties),
+		Varieties: varieties,
+		UpdatedAt: updatedAt,
+	}
+	if lang != "" {
+		// La forma por defecto no tiene nombre propio y usa el de la especie.
+		served := domain.NewServedLanguages(lang)
+		var speciesLang string
+		list.LocalizedSpecies, speciesLang = species.Names.Lookup(lang)
+		served.Add(speciesLang)
+		for j := range varieties {
+			var formLang string
+			varieties[j].LocalizedName, formLang = uc.names("pokemon-form", varieties[j].Name).Lookup(lang)
+			if varieties[j].LocalizedName == "" {
+				varieties[j].LocalizedName, formLang = list.LocalizedSpecies, speciesLang
+			}
+			served.Add(formLang)
+		}
+		list.Language = served.String()
+	}
+	return list, nil
+}
+
+func (uc *pokemonUseCase) GetPokemonSprite(id, kind string, size int, format string) (*domain.SpriteImage, error) {
+	pokemon, err := uc.resolvePokemon(strings.ToLower(id))
+	if err != nil {
+		return nil, err
+	}
+
+	url, ok := pokemon.Sprites.SpriteURL(kind)
+	if !ok {
+		return nil, domain.ErrSpriteNotFound
+	}
+
+	return uc.pokeAPIRepo.GetSpriteImage(url, size, format)
+}
+
+// LocalizePokemon devuelve una copia con el nombre, la categoría, la
+// descripción y las habilidades en lang (o en inglés si no hay traducción).
+// Language es el idioma en que se encontró el nombre. Los textos son
+// complementarios, así que un fallo al obtenerlos no es un error.
+func (uc *pokemonUseCase) LocalizePokemon(pokemon *domain.Pokemon, lang string) *domain.Pokemon {
+	localized := *pokemon
+	localized.Language = lang
+
+	if speciesID, ok := domain.ResourceID(pokemon.Species.URL); ok {
+		if species, err := uc.pokeAPIRepo.GetPokemonSpecies(speciesID); err == nil {
+			localized.LocalizedName, localized.Language = species.Names.Lookup(lang)
+			if localized.Language == "" {
+				localized.Language = lang
+			}
+			localized.Genus = species.Genera.Get(lang)
+			localized.Description = species.FlavorTexts.Get(lang)
+		}
+	}
+
+	localized.Abilities = make([]domain.Ability, len(pokemon.Abilities))
+	for i, a := range pokemon.Abilities {
+		if detail, err := uc.pokeAPIRepo.GetAbilityByName(a.Ability.Name); err == nil {
+			a.Ability.LocalizedName = detail.Names.Get(lang)
+		}
+		localized.Abilities[i] = a
+	}
+	return &localized
+}
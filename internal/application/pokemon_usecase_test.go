@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reto-pokemon-api/internal/domain"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -48,6 +49,102 @@ func (m *MockPokeAPIRepository) GetPokemonAll(filter domain.PokemonFilter) (*dom
 	return args.Get(0).(*domain.PokemonList), args.Error(1)
 }
 
+func (m *MockPokeAPIRepository) GetPokemonSummaries(filter domain.PokemonFilter) (*domain.PokemonSummaryList, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonSummaryList), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetPokemonMoves(id int) ([]domain.PokemonMove, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PokemonMove), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetMoveByName(name string) (*domain.Move, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Move), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetAbilityByName(name string) (*domain.AbilityDetail, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AbilityDetail), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetItemByName(name string) (*domain.Item, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Item), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetBerryByName(name string) (*domain.Berry, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Berry), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetPokemonEncounters(id int) ([]domain.Encounter, time.Time, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, time.Time{}, args.Error(2)
+	}
+	return args.Get(0).([]domain.Encounter), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockPokeAPIRepository) GetGeneration(id string) (*domain.Generation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Generation), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetPokedex(name string) (*domain.Pokedex, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Pokedex), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetPokemonSpecies(id int) (*domain.PokemonSpecies, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PokemonSpecies), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetSpriteImage(url string, size int, format string) (*domain.SpriteImage, error) {
+	args := m.Called(url, size, format)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SpriteImage), args.Error(1)
+}
+
+func (m *MockPokeAPIRepository) GetResourceNames(resource, name string) (domain.LocalizedStrings, error) {
+	args := m.Called(resource, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.LocalizedStrings), args.Error(1)
+}
+
 func TestPokemonUseCase_GetPokemonByID(t *testing.T) {
 
 	mockPokeAPIRepo := new(MockPokeAPIRepository)
@@ -56,12 +153,14 @@ func TestPokemonUseCase_GetPokemonByID(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 
+		fetchedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
 		apiPokemon := &domain.Pokemon{
 			Name:      "pikachu",
 			Height:    4,
 			Weight:    60,
 			BaseExp:   112,
 			PokeAPIID: 25,
+			UpdatedAt: fetchedAt,
 			Types: []domain.Type{
 				{
 					Slot: 1,
@@ -87,6 +186,7 @@ func TestPokemonUseCase_GetPokemonByID(t *testing.T) {
 		assert.Equal(t, 60, result.Weight)
 
 		assert.False(t, result.IsFavorite)
+		assert.Equal(t, fetchedAt, result.UpdatedAt)
 
 		mockPokeAPIRepo.AssertExpectations(t)
 	})
@@ -99,6 +199,22 @@ func TestPokemonUseCase_GetPokemonByID(t *testing.T) {
 		mockPokeAPIRepo.AssertExpectations(t)
 
 	})
+
+	t.Run("Success - held items with rarity by version", func(t *testing.T) {
+		heldItems := []domain.HeldItem{{
+			Item: domain.ItemInfo{Name: "light-ball", URL: "https://pokeapi.co/api/v2/item/213/"},
+			VersionDetails: []domain.HeldItemVersion{
+				{Rarity: 5, Version: "yellow"},
+				{Rarity: 1, Version: "x"},
+			},
+		}}
+		mockPokeAPIRepo.On("GetPokemonByID", 26).Return(&domain.Pokemon{Name: "raichu", HeldItems: heldItems}, nil)
+
+		result, err := useCase.GetPokemonByID("26")
+
+		assert.NoError(t, err)
+		assert.Equal(t, heldItems, result.HeldItems)
+	})
 }
 
 func TestPokemonUseCase_GetPokemonAll(t *testing.T) {
@@ -165,6 +281,28 @@ func TestPokemonUseCase_GetPokemonAll(t *testing.T) {
 		mockPokeAPIRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success - filtered by ability", func(t *testing.T) {
+		filter := domain.PokemonFilter{Ability: "Static", Limit: 1, Offset: 1}
+
+		mockPokeAPIRepo.On("GetAbilityByName", "static").Return(&domain.AbilityDetail{
+			Name: "static",
+			Pokemon: []domain.AbilityPokemon{
+				{Name: "pikachu"},
+				{Name: "raichu"},
+				{Name: "electabuzz", IsHidden: true},
+			},
+		}, nil)
+		mockPokeAPIRepo.On("GetPokemonByName", "raichu").Return(&domain.Pokemon{ID: 26, Name: "raichu"}, nil)
+
+		result, err := useCase.GetPokemonAll(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Count)
+		assert.Len(t, *result.Pokemons, 1)
+		assert.Equal(t, "raichu", (*result.Pokemons)[0].Name)
+		mockPokeAPIRepo.AssertNotCalled(t, "GetPokemonAll", filter)
+	})
+
 	t.Run("Error - API returns error", func(t *testing.T) {
 		filter := domain.PokemonFilter{}
 
@@ -179,3 +317,495 @@ func TestPokemonUseCase_GetPokemonAll(t *testing.T) {
 		mockPokeAPIRepo.AssertExpectations(t)
 	})
 }
+
+func TestPokemonUseCase_GetPokemonSummaries(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	t.Run("Success - delegates to repository", func(t *testing.T) {
+		filter := domain.PokemonFilter{Limit: 2}
+		expected := &domain.PokemonSummaryList{
+			Count:    1302,
+			Pokemons: []domain.PokemonSummary{{ID: 1, Name: "bulbasaur"}, {ID: 2, Name: "ivysaur"}},
+		}
+		mockPokeAPIRepo.On("GetPokemonSummaries", filter).Return(expected, nil)
+
+		result, err := useCase.GetPokemonSummaries(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+		mockPokeAPIRepo.AssertNotCalled(t, "GetPokemonAll", filter)
+	})
+
+	t.Run("Success - filtered by ability without fetching each pokemon", func(t *testing.T) {
+		filter := domain.PokemonFilter{Ability: "Static", Limit: 2, Offset: 1}
+		mockPokeAPIRepo.On("GetAbilityByName", "static").Return(&domain.AbilityDetail{
+			Name: "static",
+			Pokemon: []domain.AbilityPokemon{
+				{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon/25/"},
+				{Name: "raichu", URL: "https://pokeapi.co/api/v2/pokemon/26/"},
+				{Name: "electabuzz", URL: "https://pokeapi.co/api/v2/pokemon/125/", IsHidden: true},
+			},
+		}, nil)
+
+		result, err := useCase.GetPokemonSummaries(filter)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Count)
+		assert.Equal(t, []domain.PokemonSummary{{ID: 26, Name: "raichu"}, {ID: 125, Name: "electabuzz"}}, result.Pokemons)
+		mockPokeAPIRepo.AssertNotCalled(t, "GetPokemonByName", "raichu")
+	})
+}
+
+func TestPokemonUseCase_GetPokemonStats(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	garchomp := &domain.Pokemon{
+		ID:        445,
+		Name:      "garchomp",
+		UpdatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Stats: []domain.Stat{
+			{BaseStat: 108, Stat: domain.StatInfo{Name: "hp"}},
+			{BaseStat: 130, Stat: domain.StatInfo{Name: "attack"}},
+			{BaseStat: 95, Stat: domain.StatInfo{Name: "defense"}},
+			{BaseStat: 80, Stat: domain.StatInfo{Name: "special-attack"}},
+			{BaseStat: 85, Stat: domain.StatInfo{Name: "special-defense"}},
+			{BaseStat: 102, Stat: domain.StatInfo{Name: "speed"}},
+		},
+	}
+	mockPokeAPIRepo.On("GetPokemonByID", 445).Return(garchomp, nil)
+
+	t.Run("Success - level 100 adamant", func(t *testing.T) {
+		req := domain.StatsRequest{
+			Level:  100,
+			Nature: "adamant",
+			IVs:    [6]int{31, 31, 31, 31, 31, 31},
+			EVs:    [6]int{0, 252, 0, 0, 4, 252},
+		}
+
+		result, err := useCase.GetPokemonStats("445", req, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 357, result.Stats["hp"])
+		assert.Equal(t, 394, result.Stats["attack"])
+		assert.Equal(t, 176, result.Stats["special-attack"])
+		assert.Equal(t, 303, result.Stats["speed"])
+		assert.Equal(t, garchomp.UpdatedAt, result.UpdatedAt)
+	})
+
+	t.Run("Success - stat and nature names in the requested language", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetResourceNames", "stat", "attack").Return(domain.LocalizedStrings{"en": "Attack", "es": "Ataque"}, nil)
+		mockPokeAPIRepo.On("GetResourceNames", "stat", mock.Anything).Return(domain.LocalizedStrings{}, nil)
+		mockPokeAPIRepo.On("GetResourceNames", "nature", "adamant").Return(domain.LocalizedStrings{"en": "Adamant", "es": "Firme"}, nil)
+
+		result, err := useCase.GetPokemonStats("445", domain.StatsRequest{Level: 50, Nature: "adamant"}, "es")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Ataque", result.StatNames["attack"])
+		assert.Len(t, result.StatNames, 6)
+		assert.Equal(t, "Firme", result.Nature.LocalizedName)
+		assert.Equal(t, "es", result.Language)
+	})
+
+	t.Run("Error - unknown nature", func(t *testing.T) {
+		result, err := useCase.GetPokemonStats("445", domain.StatsRequest{Level: 50, Nature: "grumpy"}, "")
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidStatParams, err)
+	})
+}
+
+func TestPokemonUseCase_ComparePokemon(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	pikachu := &domain.Pokemon{
+		ID: 25, Name: "pikachu", Height: 4,
+		Species:   domain.SpeciesRef{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon-species/25/"},
+		Types:     []domain.Type{{Slot: 1, Type: domain.TypeInfo{Name: "electric"}}},
+		Abilities: []domain.Ability{{Slot: 1, Ability: domain.AbilityInfo{Name: "static"}}, {Slot: 3, IsHidden: true, Ability: domain.AbilityInfo{Name: "lightning-rod"}}},
+		Stats:     []domain.Stat{{BaseStat: 90, Stat: domain.StatInfo{Name: "speed"}}},
+	}
+	eevee := &domain.Pokemon{
+		ID: 133, Name: "eevee", Height: 3,
+		Types:     []domain.Type{{Slot: 1, Type: domain.TypeInfo{Name: "normal"}}},
+		Abilities: []domain.Ability{{Slot: 1, Ability: domain.AbilityInfo{Name: "run-away"}}, {Slot: 2, Ability: domain.AbilityInfo{Name: "static"}}},
+		Stats:     []domain.Stat{{BaseStat: 55, Stat: domain.StatInfo{Name: "speed"}}},
+	}
+	varieties := []domain.Variety{{Name: "pikachu", IsDefault: true}, {Name: "pikachu-gmax"}}
+	mockPokeAPIRepo.On("GetPokemonByID", 25).Return(pikachu, nil)
+	mockPokeAPIRepo.On("GetPokemonByName", "eevee").Return(eevee, nil)
+	mockPokeAPIRepo.On("GetPokemonByName", "pikachu").Return(pikachu, nil)
+	mockPokeAPIRepo.On("GetPokemonSpecies", 25).Return(&domain.PokemonSpecies{
+		ID:        25,
+		Varieties: varieties,
+		Names:     domain.LocalizedStrings{"en": "Pikachu", "ja": "ピカチュウ"},
+	}, nil)
+	mockPokeAPIRepo.On("GetAbilityByName", mock.Anything).Return(nil, domain.ErrAbilityNotFound)
+
+	t.Run("Success - winners and deltas for every numeric field", func(t *testing.T) {
+		result, err := useCase.ComparePokemon([]string{"25", "eevee"}, "")
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Pokemon, 2)
+		fields := map[string]domain.ComparisonField{}
+		for _, field := range result.Fields {
+			fields[field.Field] = field
+		}
+		assert.Equal(t, []int{90, 55}, fields["speed"].Values)
+		assert.Equal(t, []int{0, -35}, fields["speed"].Deltas)
+		assert.Equal(t, []int{25}, fields["speed"].Winners)
+		assert.Equal(t, []int{4, 3}, fields["height"].Values)
+		assert.Equal(t, []int{0, -1}, fields["height"].Deltas)
+		assert.Equal(t, []int{25}, fields["height"].Winners)
+		assert.Equal(t, []int{0, 0}, fields["weight"].Deltas)
+		assert.Equal(t, []int{25, 133}, fields["weight"].Winners)
+		assert.Equal(t, []int{25, 133}, fields["base_experience"].Winners)
+		for _, field := range result.Fields {
+			assert.True(t, field.HigherIsBetter, field.Field)
+		}
+	})
+
+	t.Run("Success - types and abilities are compared rows", func(t *testing.T) {
+		result, err := useCase.ComparePokemon([]string{"25", "eevee"}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.ComparisonTrait{
+			{Field: "types", Values: [][]string{{"electric"}, {"normal"}}, Shared: []string{}},
+			{Field: "abilities", Values: [][]string{{"static", "lightning-rod"}, {"run-away", "static"}}, Shared: []string{"static"}},
+		}, result.Traits)
+	})
+
+	t.Run("Success - resolved like the detail, with varieties and localization", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetResourceNames", "stat", "speed").Return(domain.LocalizedStrings{"ja": "すばやさ"}, nil)
+		mockPokeAPIRepo.On("GetResourceNames", "stat", mock.Anything).Return(domain.LocalizedStrings{}, nil)
+
+		result, err := useCase.ComparePokemon([]string{"25", "eevee"}, "ja")
+
+		assert.NoError(t, err)
+		assert.Equal(t, varieties, result.Pokemon[0].Varieties)
+		assert.Equal(t, "ピカチュウ", result.Pokemon[0].LocalizedName)
+		assert.Equal(t, "ja", result.Language)
+		assert.Nil(t, pikachu.Varieties)
+		for _, field := range result.Fields {
+			if field.Field == "speed" {
+				assert.Equal(t, "すばやさ", field.LocalizedField)
+			}
+		}
+	})
+
+	t.Run("Error - the same pokemon twice", func(t *testing.T) {
+		result, err := useCase.ComparePokemon([]string{"25", "pikachu"}, "")
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidPokemonData, err)
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetPokemonByID", 99999).Return(nil, domain.ErrPokemonNotFound)
+
+		result, err := useCase.ComparePokemon([]string{"25", "99999"}, "")
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrPokemonNotFound, err)
+	})
+}
+
+func TestPokemonUseCase_GetPokemonMoves(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	moves := []domain.PokemonMove{
+		{Move: domain.MoveInfo{Name: "thunder-shock"}, VersionGroupDetails: []domain.MoveLearnDetail{{LearnMethod: "level-up", VersionGroup: "red-blue"}}},
+		{Move: domain.MoveInfo{Name: "thunderbolt"}, VersionGroupDetails: []domain.MoveLearnDetail{{LearnMethod: "machine", VersionGroup: "red-blue"}}},
+	}
+	mockPokeAPIRepo.On("GetPokemonMoves", 25).Return(moves, nil)
+	fetchedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockPokeAPIRepo.On("GetPokemonByID", 25).Return(&domain.Pokemon{ID: 25, Name: "pikachu", UpdatedAt: fetchedAt}, nil)
+	mockPokeAPIRepo.On("GetMoveByName", "thunder-shock").Return(&domain.Move{
+		Name:        "thunder-shock",
+		Names:       domain.LocalizedStrings{"en": "Thunder Shock", "it": "Tuonoshock"},
+		FlavorTexts: domain.LocalizedStrings{"en": "An electric attack.", "it": "Attacco elettrico."},
+	}, nil)
+	mockPokeAPIRepo.On("GetMoveByName", "thunderbolt").Return(nil, domain.ErrMoveNotFound)
+
+	t.Run("Success - move names in the requested language", func(t *testing.T) {
+		result, err := useCase.GetPokemonMoves("25", domain.MoveFilter{}, "it")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Tuonoshock", result.Moves[0].Move.LocalizedName)
+		assert.Empty(t, result.Moves[1].Move.LocalizedName)
+		assert.Equal(t, fetchedAt, result.UpdatedAt)
+		assert.Equal(t, "it", result.Language)
+		assert.Empty(t, moves[0].Move.LocalizedName)
+	})
+
+	t.Run("Success - reports the fallback language", func(t *testing.T) {
+		result, err := useCase.GetPokemonMoves("25", domain.MoveFilter{}, "fr")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Thunder Shock", result.Moves[0].Move.LocalizedName)
+		assert.Equal(t, "en", result.Language)
+	})
+
+	t.Run("Success - a single move without the texts in every language", func(t *testing.T) {
+		result, err := useCase.GetMove("Thunder-Shock", "it")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Tuonoshock", result.LocalizedName)
+		assert.Equal(t, "Attacco elettrico.", result.FlavorText)
+		assert.Equal(t, "it", result.Language)
+		assert.Nil(t, result.Names)
+		assert.Nil(t, result.FlavorTexts)
+	})
+}
+
+func TestPokemonUseCase_GetPokemonEncounters(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	encounters := []domain.Encounter{
+		{Location: "viridian-forest-area", Version: "red", Method: "walk", Chance: 5, MinLevel: 3, MaxLevel: 5},
+		{Location: "viridian-forest-area", Version: "blue", Method: "walk", Chance: 5, MinLevel: 3, MaxLevel: 5},
+		{Location: "power-plant-area", Version: "red", Method: "walk", Chance: 25, MinLevel: 21, MaxLevel: 24},
+	}
+	fetchedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockPokeAPIRepo.On("GetPokemonEncounters", 25).Return(encounters, fetchedAt, nil)
+
+	t.Run("Success - filtered by version", func(t *testing.T) {
+		result, err := useCase.GetPokemonEncounters("25", domain.EncounterFilter{Version: "red"}, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Count)
+		assert.Equal(t, "power-plant-area", result.Encounters[1].Location)
+		assert.Equal(t, fetchedAt, result.UpdatedAt)
+	})
+
+	t.Run("Success - location and version names in the requested language", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetResourceNames", "location-area", "viridian-forest-area").Return(domain.LocalizedStrings{"en": "Viridian Forest", "fr": "Forêt de Jade"}, nil).Once()
+		mockPokeAPIRepo.On("GetResourceNames", "location-area", "power-plant-area").Return(nil, domain.ErrResourceNotFound)
+		mockPokeAPIRepo.On("GetResourceNames", "version", "red").Return(domain.LocalizedStrings{"en": "Red", "fr": "Rouge"}, nil).Once()
+		mockPokeAPIRepo.On("GetResourceNames", "version", "blue").Return(domain.LocalizedStrings{"en": "Blue", "fr": "Bleue"}, nil).Once()
+
+		result, err := useCase.GetPokemonEncounters("25", domain.EncounterFilter{}, "fr")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Forêt de Jade", result.Encounters[0].LocalizedLocation)
+		assert.Equal(t, "Rouge", result.Encounters[0].LocalizedVersion)
+		assert.Equal(t, "Bleue", result.Encounters[1].LocalizedVersion)
+		assert.Empty(t, result.Encounters[2].LocalizedLocation)
+		assert.Equal(t, "fr", result.Language)
+		assert.Empty(t, encounters[0].LocalizedLocation)
+		mockPokeAPIRepo.AssertExpectations(t)
+	})
+
+	t.Run("Error - invalid id", func(t *testing.T) {
+		result, err := useCase.GetPokemonEncounters("pikachu", domain.EncounterFilter{}, "")
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidPokemonData, err)
+	})
+}
+
+func TestPokemonUseCase_GetItem(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	t.Run("Success - name is case-insensitive", func(t *testing.T) {
+		item := &domain.Item{
+			ID: 213, Name: "light-ball", Category: "species-specific",
+			HeldBy: []domain.ItemHolder{{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon/25/"}},
+		}
+		mockPokeAPIRepo.On("GetItemByName", "light-ball").Return(item, nil)
+
+		result, err := useCase.GetItem("Light-Ball", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, item, result)
+		mockPokeAPIRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - localized without the texts in every language", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetItemByName", "potion").Return(&domain.Item{
+			ID: 17, Name: "potion",
+			Names:       domain.LocalizedStrings{"en": "Potion", "de": "Trank"},
+			FlavorTexts: domain.LocalizedStrings{"en": "Restores 20 HP.", "de": "Füllt 20 KP auf."},
+		}, nil)
+
+		result, err := useCase.GetItem("potion", "de-AT")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Trank", result.LocalizedName)
+		assert.Equal(t, "Füllt 20 KP auf.", result.FlavorText)
+		assert.Equal(t, "de", result.Language)
+		assert.Nil(t, result.Names)
+		assert.Nil(t, result.FlavorTexts)
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetItemByName", "missingno").Return(nil, domain.ErrItemNotFound)
+
+		result, err := useCase.GetItem("missingno", "")
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrItemNotFound, err)
+	})
+}
+
+func TestPokemonUseCase_GetBerry(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	t.Run("Success - name is case-insensitive", func(t *testing.T) {
+		berry := &domain.Berry{
+			ID: 1, Name: "cheri", Item: "cheri-berry", Firmness: "soft",
+			Flavors: []domain.BerryFlavor{{Flavor: "spicy", Potency: 10}},
+		}
+		mockPokeAPIRepo.On("GetBerryByName", "cheri").Return(berry, nil)
+
+		result, err := useCase.GetBerry("CHERI", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, berry, result)
+		mockPokeAPIRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - localized with the names of its item", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetItemByName", "cheri-berry").Return(&domain.Item{
+			Name:  "cheri-berry",
+			Names: domain.LocalizedStrings{"en": "Cheri Berry", "es": "Baya Zreza"},
+		}, nil)
+
+		result, err := useCase.GetBerry("cheri", "es")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Baya Zreza", result.LocalizedName)
+		assert.Equal(t, "es", result.Language)
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetBerryByName", "missingno").Return(nil, domain.ErrBerryNotFound)
+
+		result, err := useCase.GetBerry("missingno", "")
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrBerryNotFound, err)
+	})
+}
+
+func TestPokemonUseCase_GetPokemonByGeneration(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	mockPokeAPIRepo.On("GetGeneration", "1").Return(&domain.Generation{
+		ID:   1,
+		Name: "generation-i",
+		Species: []domain.SpeciesRef{
+			{Name: "ivysaur", URL: "https://pokeapi.co/api/v2/pokemon-species/2/"},
+			{Name: "bulbasaur", URL: "https://pokeapi.co/api/v2/pokemon-species/1/"},
+			{Name: "venusaur", URL: "https://pokeapi.co/api/v2/pokemon-species/3/"},
+		},
+	}, nil)
+	mockPokeAPIRepo.On("GetPokemonByID", 1).Return(&domain.Pokemon{ID: 1, Name: "bulbasaur"}, nil)
+	mockPokeAPIRepo.On("GetPokemonByID", 2).Return(&domain.Pokemon{ID: 2, Name: "ivysaur"}, nil)
+
+	result, err := useCase.GetPokemonByGeneration("1", domain.PokemonFilter{Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Count)
+	assert.Len(t, *result.Pokemons, 2)
+	assert.Equal(t, "bulbasaur", (*result.Pokemons)[0].Name)
+	assert.Equal(t, "ivysaur", (*result.Pokemons)[1].Name)
+	mockPokeAPIRepo.AssertNotCalled(t, "GetPokemonByID", 3)
+}
+
+func TestPokemonUseCase_GetPokemonForms(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	speciesRef := domain.SpeciesRef{Name: "raichu", URL: "https://pokeapi.co/api/v2/pokemon-species/26/"}
+	raichu := &domain.Pokemon{ID: 26, Name: "raichu", Species: speciesRef, Types: []domain.Type{{Slot: 1, Type: domain.TypeInfo{Name: "electric"}}}}
+	alola := &domain.Pokemon{ID: 10100, Name: "raichu-alola", Species: speciesRef, Types: []domain.Type{
+		{Slot: 1, Type: domain.TypeInfo{Name: "electric"}},
+		{Slot: 2, Type: domain.TypeInfo{Name: "psychic"}},
+	}}
+
+	mockPokeAPIRepo.On("GetPokemonByID", 26).Return(raichu, nil)
+	mockPokeAPIRepo.On("GetPokemonByName", "raichu").Return(raichu, nil)
+	mockPokeAPIRepo.On("GetPokemonByName", "raichu-alola").Return(alola, nil)
+	mockPokeAPIRepo.On("GetPokemonSpecies", 26).Return(&domain.PokemonSpecies{
+		ID:    26,
+		Name:  "raichu",
+		Names: domain.LocalizedStrings{"en": "Raichu", "ja": "ライチュウ"},
+		Varieties: []domain.Variety{
+			{Name: "raichu", IsDefault: true},
+			{Name: "raichu-alola"},
+		},
+	}, nil)
+
+	t.Run("Success - lists every variety", func(t *testing.T) {
+		result, err := useCase.GetPokemonForms("26", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Count)
+		assert.True(t, result.Varieties[0].IsDefault)
+		assert.Equal(t, "raichu-alola", result.Varieties[1].Name)
+		assert.Len(t, result.Varieties[1].Types, 2)
+	})
+
+	t.Run("Success - localized, falling back to the species name", func(t *testing.T) {
+		mockPokeAPIRepo.On("GetResourceNames", "pokemon-form", "raichu").Return(nil, domain.ErrResourceNotFound)
+		mockPokeAPIRepo.On("GetResourceNames", "pokemon-form", "raichu-alola").Return(domain.LocalizedStrings{"ja": "アローラのすがた"}, nil)
+
+		result, err := useCase.GetPokemonForms("26", "ja")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ライチュウ", result.LocalizedSpecies)
+		assert.Equal(t, "ライチュウ", result.Varieties[0].LocalizedName)
+		assert.Equal(t, "アローラのすがた", result.Varieties[1].LocalizedName)
+		assert.Equal(t, "ja", result.Language)
+	})
+
+	t.Run("Success - varieties linked on pokemon response", func(t *testing.T) {
+		result, err := useCase.GetPokemonByName("raichu-alola")
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Varieties, 2)
+		assert.Nil(t, alola.Varieties)
+	})
+}
+
+func TestPokemonUseCase_LocalizePokemon(t *testing.T) {
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewPokemonUseCase(mockPokeAPIRepo)
+
+	pikachu := &domain.Pokemon{
+		ID:        25,
+		Name:      "pikachu",
+		Species:   domain.SpeciesRef{Name: "pikachu", URL: "https://pokeapi.co/api/v2/pokemon-species/25/"},
+		Abilities: []domain.Ability{{Slot: 1, Ability: domain.AbilityInfo{Name: "static"}}},
+	}
+	mockPokeAPIRepo.On("GetPokemonSpecies", 25).Return(&domain.PokemonSpecies{
+		ID:          25,
+		Name:        "pikachu",
+		Names:       domain.LocalizedStrings{"en": "Pikachu", "ja": "ピカチュウ"},
+		Genera:      domain.LocalizedStrings{"en": "Mouse Pokémon", "es": "Pokémon Ratón"},
+		FlavorTexts: domain.LocalizedStrings{"en": "It keeps its tail raised.", "es": "Levanta su cola."},
+	}, nil)
+	mockPokeAPIRepo.On("GetAbilityByName", "static").Return(&domain.AbilityDetail{
+		Name:  "static",
+		Names: domain.LocalizedStrings{"en": "Static", "es": "Elec. Estática"},
+	}, nil)
+
+	t.Run("Success - regional tag falls back to base language and english", func(t *testing.T) {
+		result := useCase.LocalizePokemon(pikachu, "es-MX")
+
+		assert.Equal(t, "Pikachu", result.LocalizedName)
+		assert.Equal(t, "en", result.Language)
+		assert.Equal(t, "Pokémon Ratón", result.Genus)
+		assert.Equal(t, "Levanta su cola.", result.Description)
+		assert.Equal(t, "Elec. Estática", result.Abilities[0].Ability.LocalizedName)
+		assert.Empty(t, pikachu.Abilities[0].Ability.LocalizedName)
+	})
+}
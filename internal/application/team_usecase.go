@@ -0,0 +1,303 @@
+package application
+
+import (
+	"sort"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type teamUseCase struct {
+	teamRepo    domain.TeamRepository
+	pokeAPIRepo domain.PokeAPIRepository
+}
+
+func NewTeamUseCase(teamRepo domain.TeamRepository, pokeAPIRepo domain.PokeAPIRepository) domain.TeamUseCase {
+	return &teamUseCase{
+		teamRepo:    teamRepo,
+		pokeAPIRepo: pokeAPIRepo,
+	}
+}
+
+func (uc *teamUseCase) CreateTeam(userID string, team *domain.Team) (*domain.Team, error) {
+	if userID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	if err := uc.validateMembers(team.Members); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	team.UserID = userID
+	team.CreatedAt = now
+	team.UpdatedAt = now
+
+	if err := uc.teamRepo.Create(team); err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (uc *teamUseCase) GetTeam(userID, id string) (*domain.Team, error) {
+	if userID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	team, err := uc.teamRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if team.UserID != userID {
+		return nil, domain.ErrForbidden
+	}
+	return team, nil
+}
+
+func (uc *teamUseCase) ListTeams(userID string) ([]domain.Team, error) {
+	if userID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+	return uc.teamRepo.ListByUser(userID)
+}
+
+func (uc *teamUseCase) UpdateTeam(userID, id string, team *domain.Team) (*domain.Team, error) {
+	existing, err := uc.GetTeam(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.validateMembers(team.Members); err != nil {
+		return nil, err
+	}
+
+	existing.Name = team.Name
+	existing.Members = team.Members
+	existing.UpdatedAt = time.Now()
+
+	if err := uc.teamRepo.Update(existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (uc *teamUseCase) DeleteTeam(userID, id string) error {
+	if _, err := uc.GetTeam(userID, id); err != nil {
+		return err
+	}
+	return uc.teamRepo.Delete(id)
+}
+
+func (uc *teamUseCase) AnalyzeTeam(userID, id string) (*domain.TeamAnalysis, error) {
+	team, err := uc.GetTeam(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*domain.Pokemon, 0, len(team.Members))
+	for _, m := range team.Members {
+		pokemon, err := uc.pokeAPIRepo.GetPokemonByID(m.PokemonID)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, pokemon)
+	}
+
+	matchups := weaknesses(members)
+	attacking, err := uc.offensiveTypes(team.Members, members)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TeamAnalysis{
+		TeamID:           team.ID,
+		Members:          memberStats(members),
+		Weaknesses:       matchups,
+		SharedWeaknesses: sharedWeaknesses(matchups),
+		OffensiveTypes:   attacking,
+		CoverageGaps:     coverageGaps(attacking),
+		StatDistribution: statDistribution(members),
+	}, nil
+}
+
+// validateMembers comprueba que cada Pokémon exista y que la habilidad elegida le pertenezca.
+func (uc *teamUseCase) validateMembers(members []domain.TeamMember) error {
+	if len(members) == 0 || len(members) > domain.MaxTeamSize {
+		return domain.ErrInvalidTeamData
+	}
+
+	for _, m := range members {
+		pokemon, err := uc.pokeAPIRepo.GetPokemonByID(m.PokemonID)
+		if err == domain.ErrPokemonNotFound {
+			return domain.ErrInvalidTeamData
+		}
+		if err != nil {
+			return err
+		}
+		if m.Ability != "" && !hasAbility(pokemon, m.Ability) {
+			return domain.ErrInvalidTeamData
+		}
+		if len(m.Moves) == 0 {
+			continue
+		}
+
+		learnset, err := uc.pokeAPIRepo.GetPokemonMoves(m.PokemonID)
+		if err != nil {
+			return err
+		}
+		for _, move := range m.Moves {
+			if !canLearn(learnset, move) {
+				return domain.ErrInvalidTeamData
+			}
+		}
+	}
+	return nil
+}
+
+func canLearn(learnset []domain.PokemonMove, move string) bool {
+	for _, m := range learnset {
+		if m.Move.Name == move {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAbility(pokemon *domain.Pokemon, ability string) bool {
+	for _, a := range pokemon.Abilities {
+		if a.Ability.Name == ability {
+			return true
+		}
+	}
+	return false
+}
+
+func memberStats(members []*domain.Pokemon) []domain.TeamMemberStats {
+	result := make([]domain.TeamMemberStats, len(members))
+	for i, p := range members {
+		stats := make(map[string]int, len(p.Stats))
+		for _, s := range p.Stats {
+			stats[s.Stat.Name] = s.BaseStat
+		}
+		result[i] = domain.TeamMemberStats{
+			PokemonID: p.ID,
+			Name:      p.Name,
+			Types:     p.TypeNames(),
+			Stats:     stats,
+			StatTotal: p.StatTotal(),
+		}
+	}
+	return result
+}
+
+func weaknesses(members []*domain.Pokemon) []domain.TypeMatchup {
+	matchups := make([]domain.TypeMatchup, 0, len(domain.PokemonTypes))
+	for _, attacking := range domain.PokemonTypes {
+		matchup := domain.TypeMatchup{Type: attacking}
+		for _, p := range members {
+			switch m := domain.TypeEffectiveness(attacking, p.TypeNames()...); {
+			case m == 0:
+				matchup.Immune++
+			case m < 1:
+				matchup.Resistant++
+			case m > 1:
+				matchup.Weak++
+				matchup.WeakTo = append(matchup.WeakTo, p.Name)
+			}
+		}
+		matchups = append(matchups, matchup)
+	}
+	return matchups
+}
+
+// sharedWeaknesses devuelve los tipos que golpean a dos o más miembros sin
+// que el resto del equipo los compense con resistencias o inmunidades.
+func sharedWeaknesses(matchups []domain.TypeMatchup) []string {
+	shared := []string{}
+	for _, m := range matchups {
+		if m.Weak >= 2 && m.Weak > m.Resistant+m.Immune {
+			shared = append(shared, m.Type)
+		}
+	}
+	return shared
+}
+
+// offensiveTypes reúne los tipos STAB de cada miembro y los tipos de sus
+// movimientos ofensivos; los movimientos de estado no cuentan para la cobertura.
+func (uc *teamUseCase) offensiveTypes(team []domain.TeamMember, members []*domain.Pokemon) ([]string, error) {
+	seen := make(map[string]bool)
+	types := []string{}
+	add := func(t string) {
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+
+	for i, p := range members {
+		for _, t := range p.TypeNames() {
+			add(t)
+		}
+		for _, name := range team[i].Moves {
+			move, err := uc.pokeAPIRepo.GetMoveByName(name)
+			if err != nil {
+				return nil, err
+			}
+			if move.IsDamaging() {
+				add(move.Type)
+			}
+		}
+	}
+
+	sort.Strings(types)
+	return types, nil
+}
+
+// coverageGaps devuelve los tipos defensores a los que ningún tipo ofensivo
+// del equipo golpea de forma súper eficaz.
+func coverageGaps(attacking []string) []string {
+	gaps := []string{}
+	for _, defending := range domain.PokemonTypes {
+		covered := false
+		for _, a := range attacking {
+			if domain.TypeEffectiveness(a, defending) > 1 {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			gaps = append(gaps, defending)
+		}
+	}
+	return gaps
+}
+
+func statDistribution(members []*domain.Pokemon) domain.StatDistribution {
+	dist := domain.StatDistribution{
+		Totals:   make(map[string]int),
+		Averages: make(map[string]float64),
+	}
+	if len(members) == 0 {
+		return dist
+	}
+
+	sum := 0
+	for i, p := range members {
+		for _, s := range p.Stats {
+			dist.Totals[s.Stat.Name] += s.BaseStat
+		}
+
+		total := p.StatTotal()
+		sum += total
+		if i == 0 || total < dist.MinStatTotal {
+			dist.MinStatTotal = total
+		}
+		if total > dist.MaxStatTotal {
+			dist.MaxStatTotal = total
+		}
+	}
+
+	for name, total := range dist.Totals {
+		dist.Averages[name] = float64(total) / float64(len(members))
+	}
+	dist.AvgStatTotal = float64(sum) / float64(len(members))
+	return dist
+}
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"reto-pokemon-api/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTeamRepository struct {
+	mock.Mock
+}
+
+func (m *MockTeamRepository) Create(team *domain.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetByID(id string) (*domain.Team, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) ListByUser(userID string) ([]domain.Team, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) Update(team *domain.Team) error {
+	args := m.Called(team)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func testPokemon(id int, name string, types ...string) *domain.Pokemon {
+	pokemon := &domain.Pokemon{ID: id, Name: name}
+	for i, t := range types {
+		pokemon.Types = append(pokemon.Types, domain.Type{Slot: i + 1, Type: domain.TypeInfo{Name: t}})
+	}
+	pokemon.Stats = []domain.Stat{
+		{BaseStat: 50, Stat: domain.StatInfo{Name: "hp"}},
+		{BaseStat: 60, Stat: domain.StatInfo{Name: "speed"}},
+	}
+	return pokemon
+}
+
+func TestTeamUseCase_CreateTeam(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewTeamUseCase(mockTeamRepo, mockPokeAPIRepo)
+
+	t.Run("Error - missing user", func(t *testing.T) {
+		result, err := useCase.CreateTeam("", &domain.Team{Name: "equipo"})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+
+	t.Run("Error - ability does not belong to pokemon", func(t *testing.T) {
+		pikachu := testPokemon(25, "pikachu", "electric")
+		pikachu.Abilities = []domain.Ability{{Ability: domain.AbilityInfo{Name: "static"}}}
+		mockPokeAPIRepo.On("GetPokemonByID", 25).Return(pikachu, nil)
+
+		team := &domain.Team{
+			Name:    "equipo",
+			Members: []domain.TeamMember{{PokemonID: 25, Ability: "blaze"}},
+		}
+		result, err := useCase.CreateTeam("ash", team)
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidTeamData, err)
+		mockTeamRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
+
+func TestTeamUseCase_AnalyzeTeam(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	mockPokeAPIRepo := new(MockPokeAPIRepository)
+	useCase := NewTeamUseCase(mockTeamRepo, mockPokeAPIRepo)
+
+	team := &domain.Team{
+		ID:     "t1",
+		UserID: "ash",
+		Members: []domain.TeamMember{
+			{PokemonID: 6},
+			{PokemonID: 12, Moves: []string{"psychic", "sleep-powder"}},
+		},
+	}
+	mockTeamRepo.On("GetByID", "t1").Return(team, nil)
+	mockPokeAPIRepo.On("GetPokemonByID", 6).Return(testPokemon(6, "charizard", "fire", "flying"), nil)
+	mockPokeAPIRepo.On("GetPokemonByID", 12).Return(testPokemon(12, "butterfree", "bug", "flying"), nil)
+	power := 90
+	mockPokeAPIRepo.On("GetMoveByName", "psychic").Return(&domain.Move{Name: "psychic", Type: "psychic", DamageClass: "special", Power: &power}, nil)
+	mockPokeAPIRepo.On("GetMoveByName", "sleep-powder").Return(&domain.Move{Name: "sleep-powder", Type: "grass", DamageClass: "status"}, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		analysis, err := useCase.AnalyzeTeam("ash", "t1")
+
+		assert.NoError(t, err)
+		assert.Contains(t, analysis.SharedWeaknesses, "rock")
+		assert.Equal(t, []string{"bug", "fire", "flying", "psychic"}, analysis.OffensiveTypes)
+		assert.Contains(t, analysis.CoverageGaps, "water")
+		assert.NotContains(t, analysis.CoverageGaps, "grass")
+		assert.Equal(t, 100, analysis.StatDistribution.Totals["hp"])
+		assert.Equal(t, 110.0, analysis.StatDistribution.AvgStatTotal)
+	})
+
+	t.Run("Error - team owned by another user", func(t *testing.T) {
+		analysis, err := useCase.AnalyzeTeam("gary", "t1")
+
+		assert.Nil(t, analysis)
+		assert.Equal(t, domain.ErrForbidden, err)
+	})
+}
@@ -0,0 +1,212 @@
+package delivery
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressMinSize es el tamaño a partir del cual compensa comprimir: por
+// debajo, las cabeceras y el marco del códec pesan más que el ahorro.
+const compressMinSize = 1024
+
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// encoders se prueban en este orden cuando el cliente les da la misma
+// preferencia (q). Los niveles son los habituales para compresión al vuelo:
+// los máximos de brotli y zstd cuestan mucha más CPU por muy poca ganancia.
+var encoders = []struct {
+	name string
+	pool *sync.Pool
+}{
+	{"br", &sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(nil, 5)
+	}}},
+	{"zstd", &sync.Pool{New: func() interface{} {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return enc
+	}}},
+	{"gzip", &sync.Pool{New: func() interface{} {
+		enc, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return enc
+	}}},
+}
+
+// CompressionMiddleware comprime las respuestas JSON y de texto con brotli,
+// zstd o gzip según Accept-Encoding. La decisión se toma con los primeros
+// bytes del cuerpo, así que también vale para las respuestas en streaming.
+func CompressionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		name, pool := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if pool == nil {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &compressWriter{ResponseWriter: original, encoding: name, pool: pool}
+		c.Writer = w
+		defer func() {
+			if err := w.close(); err != nil {
+				c.Error(err)
+			}
+			c.Writer = original
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding elige la codificación con mayor q de Accept-Encoding.
+// Una codificación no listada toma el q de "*"; q=0 la descarta.
+func negotiateEncoding(header string) (string, *sync.Pool) {
+	if header == "" {
+		return "", nil
+	}
+
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		weights[name] = q
+	}
+
+	bestName, bestQ := "", 0.0
+	var bestPool *sync.Pool
+	for _, enc := range encoders {
+		q, ok := weights[enc.name]
+		if !ok {
+			q = weights["*"]
+		}
+		if q > bestQ {
+			bestName, bestQ, bestPool = enc.name, q, enc.pool
+		}
+	}
+	return bestName, bestPool
+}
+
+// compressWriter retiene el cuerpo hasta tener compressMinSize bytes (o hasta
+// el final de la respuesta) y solo entonces decide si comprime. Mientras
+// tanto las cabeceras no se envían, así que todavía pueden ajustarse.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	pool     *sync.Pool
+	enc      encoder
+	buf      []byte
+	decided  bool
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.enc != nil {
+		return w.enc.Write(p)
+	}
+	if w.decided {
+		return w.ResponseWriter.Write(p)
+	}
+	if !w.compressible() {
+		w.decided = true
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < compressMinSize {
+		return len(p), nil
+	}
+	if err := w.start(); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush no puede esperar a compressMinSize: si el handler quiere enviar ya lo
+// que tiene, se decide con lo acumulado.
+func (w *compressWriter) Flush() {
+	if !w.decided && len(w.buf) > 0 {
+		if err := w.start(); err != nil {
+			return
+		}
+	}
+	if w.enc != nil {
+		if err := w.enc.Flush(); err != nil {
+			return
+		}
+	}
+	w.ResponseWriter.Flush()
+}
+
+// compressible descarta las respuestas que ya traen codificación, las que ya
+// enviaron cabeceras y los tipos que no ganan nada (los sprites PNG).
+func (w *compressWriter) compressible() bool {
+	if w.ResponseWriter.Written() || w.Header().Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasPrefix(mediaType, "text/")
+}
+
+func (w *compressWriter) start() error {
+	w.decided = true
+
+	header := w.Header()
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	// El ETag fuerte identifica los bytes sin comprimir; al cambiar la
+	// representación pasa a ser débil, que es lo que compara notModified.
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+
+	w.enc = w.pool.Get().(encoder)
+	w.enc.Reset(w.ResponseWriter)
+	buf := w.buf
+	w.buf = nil
+	_, err := w.enc.Write(buf)
+	return err
+}
+
+// close termina el flujo comprimido o, si la respuesta no llegó a
+// compressMinSize, envía tal cual lo retenido.
+func (w *compressWriter) close() error {
+	if w.enc != nil {
+		err := w.enc.Close()
+		w.enc.Reset(nil)
+		w.pool.Put(w.enc)
+		w.enc = nil
+		return err
+	}
+	if !w.decided && len(w.buf) > 0 {
+		w.decided = true
+		_, err := w.ResponseWriter.Write(w.buf)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package delivery
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{header: "", want: ""},
+		{header: "identity", want: ""},
+		{header: "gzip", want: "gzip"},
+		{header: "GZIP", want: "gzip"},
+		{header: "gzip, deflate, br, zstd", want: "br"},
+		{header: "gzip, zstd", want: "zstd"},
+		{header: "gzip;q=0.5, br;q=0.9", want: "br"},
+		{header: "br;q=0.5, gzip", want: "gzip"},
+		{header: "br;q=0, gzip;q=0.1", want: "gzip"},
+		{header: "*", want: "br"},
+		{header: "*;q=0.1, gzip", want: "gzip"},
+		{header: "*;q=0.5, br;q=0", want: "zstd"},
+		{header: "*;q=0", want: ""},
+		{header: "br;q=abc, gzip;q=0.2", want: "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			name, pool := negotiateEncoding(tt.header)
+
+			assert.Equal(t, tt.want, name)
+			assert.Equal(t, tt.want != "", pool != nil)
+		})
+	}
+}
+
+func newCompressionRouter(contentType string, body []byte) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CompressionMiddleware())
+	router.GET("/raw", func(c *gin.Context) {
+		c.Header("ETag", `"raw"`)
+		c.Data(http.StatusOK, contentType, body)
+	})
+	router.GET("/json", func(c *gin.Context) {
+		respondJSON(c, map[string]string{"body": string(body)}, time.Time{}, "no-cache")
+	})
+	return router
+}
+
+func decompress(t *testing.T, encoding string, body []byte) []byte {
+	t.Helper()
+	var reader io.Reader
+	switch encoding {
+	case "gzip":
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		reader = gz
+	case "br":
+		reader = brotli.NewReader(bytes.NewReader(body))
+	case "zstd":
+		dec, err := zstd.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		defer dec.Close()
+		reader = dec
+	default:
+		return body
+	}
+	plain, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return plain
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	small := []byte(strings.Repeat("a", compressMinSize-1))
+	large := []byte(strings.Repeat("pikachu ", compressMinSize))
+
+	tests := []struct {
+		name           string
+		contentType    string
+		body           []byte
+		acceptEncoding string
+		wantEncoding   string
+		wantETag       string
+	}{
+		{name: "Success - small body passes through", contentType: "application/json", body: small, acceptEncoding: "gzip", wantETag: `"raw"`},
+		{name: "Success - no Accept-Encoding", contentType: "application/json", body: large, wantETag: `"raw"`},
+		{name: "Success - PNG is not compressed", contentType: "image/png", body: large, acceptEncoding: "br", wantETag: `"raw"`},
+		{name: "Success - gzip", contentType: "application/json", body: large, acceptEncoding: "gzip", wantEncoding: "gzip", wantETag: `W/"raw"`},
+		{name: "Success - brotli", contentType: "text/plain; charset=utf-8", body: large, acceptEncoding: "gzip, br", wantEncoding: "br", wantETag: `W/"raw"`},
+		{name: "Success - zstd", contentType: "application/json; charset=utf-8", body: large, acceptEncoding: "zstd", wantEncoding: "zstd", wantETag: `W/"raw"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newCompressionRouter(tt.contentType, tt.body)
+			req := httptest.NewRequest(http.MethodGet, "/raw", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantEncoding, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, tt.wantETag, w.Header().Get("ETag"))
+			assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding")
+			assert.Equal(t, tt.body, decompress(t, tt.wantEncoding, w.Body.Bytes()))
+		})
+	}
+}
+
+func TestCompressionMiddleware_NotModified(t *testing.T) {
+	router := newCompressionRouter("", []byte(strings.Repeat("pikachu ", compressMinSize)))
+
+	req := httptest.NewRequest(http.MethodGet, "/json", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	weak := w.Header().Get("ETag")
+	require.True(t, strings.HasPrefix(weak, `W/"`), weak)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		ifNoneMatch    string
+	}{
+		{name: "Success - weak ETag with compression", acceptEncoding: "gzip", ifNoneMatch: weak},
+		{name: "Success - weak ETag without compression", ifNoneMatch: weak},
+		{name: "Success - strong ETag with compression", acceptEncoding: "br", ifNoneMatch: strings.TrimPrefix(weak, "W/")},
+		{name: "Success - ETag in a list", acceptEncoding: "gzip", ifNoneMatch: `"other", ` + weak},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/json", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotModified, w.Code)
+			assert.Empty(t, w.Body.Bytes())
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+		})
+	}
+
+	t.Run("Error - different ETag", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/json", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		req.Header.Set("If-None-Match", `W/"other"`)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, weak, w.Header().Get("ETag"))
+	})
+}
+
+func TestCompressionMiddleware_FlushBeforeMinSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CompressionMiddleware())
+	router.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "application/json")
+		c.Status(http.StatusOK)
+		c.Writer.WriteString(`{"a":`)
+		c.Writer.Flush()
+		c.Writer.WriteString(`1}`)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, `{"a":1}`, string(decompress(t, "gzip", w.Body.Bytes())))
+}
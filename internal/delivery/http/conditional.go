@@ -0,0 +1,101 @@
+package delivery
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachePolicy alinea el max-age de Cache-Control con el TTL de la caché del
+// servidor: un cliente no necesita revalidar antes de que lo haría el propio
+// servicio contra PokeAPI.
+type CachePolicy struct {
+	MaxAge     time.Duration
+	ListMaxAge time.Duration
+}
+
+func (p CachePolicy) cacheControl(list bool) string {
+	maxAge := p.MaxAge
+	if list {
+		maxAge = p.ListMaxAge
+	}
+	return fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+}
+
+// privateNoCache obliga a revalidar siempre los recursos de cada usuario.
+const privateNoCache = "private, no-cache"
+
+// respondJSON envía value con un ETag fuerte calculado sobre el JSON final
+// (ya localizado y con los sprites elegidos) y responde 304 si el cliente
+// tiene esa misma versión. lastModified se omite si es cero.
+func respondJSON(c *gin.Context, value interface{}, lastModified time.Time, cacheControl string) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		sendError(c, http.StatusInternalServerError, "Failed to encode response", err)
+		return
+	}
+	respondBody(c, body, lastModified, cacheControl)
+}
+
+// respondBody envía un cuerpo JSON ya codificado con un ETag fuerte calculado
+// sobre esos mismos bytes.
+func respondBody(c *gin.Context, body []byte, lastModified time.Time, cacheControl string) {
+	etag := domain.StrongETag(body)
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	setValidators(c, etag, lastModified, cacheControl)
+
+	if notModified(c, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+func setValidators(c *gin.Context, etag string, lastModified time.Time, cacheControl string) {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", cacheControl)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// notModified aplica las precondiciones de RFC 9110: If-None-Match usa
+// comparación débil y, si está presente, If-Modified-Since se ignora.
+func notModified(c *gin.Context, etag string, lastModified time.Time) bool {
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since := c.GetHeader("If-Modified-Since"); since != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(since); err == nil {
+			return !lastModified.Truncate(time.Second).After(t)
+		}
+	}
+	return false
+}
+
+// listModified es la última obtención de cualquiera de los pokémon de la página.
+func listModified(list *domain.PokemonList) time.Time {
+	var latest time.Time
+	if list == nil || list.Pokemons == nil {
+		return latest
+	}
+	for _, p := range *list.Pokemons {
+		if p.UpdatedAt.After(latest) {
+			latest = p.UpdatedAt
+		}
+	}
+	return latest
+}
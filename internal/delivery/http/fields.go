@@ -0,0 +1,117 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fieldSet es la proyección pedida con ?fields=id,name,sprites.front_default:
+// cada clave es un campo del JSON y su valor, los subcampos que se conservan
+// (nil conserva el campo entero).
+type fieldSet map[string]fieldSet
+
+// parseFields devuelve nil si no se pidió proyección.
+func parseFields(c *gin.Context) (fieldSet, error) {
+	value := c.Query("fields")
+	if value == "" {
+		return nil, nil
+	}
+
+	fields := fieldSet{}
+	for _, path := range strings.Split(value, ",") {
+		node := fields
+		segments := strings.Split(strings.TrimSpace(path), ".")
+		for i, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid field %q", path)
+			}
+			if i == len(segments)-1 {
+				node[segment] = nil
+				break
+			}
+
+			child, exists := node[segment]
+			if exists && child == nil {
+				// Ya se pidió el campo entero (sprites y sprites.front_default).
+				break
+			}
+			if child == nil {
+				child = fieldSet{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return fields, nil
+}
+
+// forList aplica la proyección a cada elemento de "pokemons" y conserva la
+// paginación.
+func (f fieldSet) forList() fieldSet {
+	if f == nil {
+		return nil
+	}
+	return fieldSet{"count": nil, "next": nil, "previous": nil, "pokemons": f}
+}
+
+// project reduce el JSON de body a los campos pedidos. Los arrays se
+// proyectan elemento a elemento (types.type.name) y los campos que no existen
+// simplemente no aparecen.
+func (f fieldSet) project(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(f.prune(value))
+}
+
+func (f fieldSet) prune(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(f))
+		for name, sub := range f {
+			field, ok := v[name]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				result[name] = field
+			} else {
+				result[name] = sub.prune(field)
+			}
+		}
+		return result
+	case []interface{}:
+		for i := range v {
+			v[i] = f.prune(v[i])
+		}
+		return v
+	}
+	return value
+}
+
+// respondProjected es respondJSON con la proyección de ?fields= ya aplicada,
+// así que el ETag corresponde a lo que realmente se envía.
+func respondProjected(c *gin.Context, value interface{}, fields fieldSet, lastModified time.Time, cacheControl string) {
+	if fields != nil {
+		body, err := json.Marshal(value)
+		if err == nil {
+			body, err = fields.project(body)
+		}
+		if err != nil {
+			sendError(c, http.StatusInternalServerError, "Failed to encode response", err)
+			return
+		}
+		value = json.RawMessage(body)
+	}
+	respondJSON(c, value, lastModified, cacheControl)
+}
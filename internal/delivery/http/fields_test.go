@@ -0,0 +1,127 @@
+package delivery
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldsContext(fields string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/pokemon/25?fields="+url.QueryEscape(fields), nil)
+	return c
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  string
+		want    fieldSet
+		wantErr bool
+	}{
+		{name: "Success - no projection", fields: "", want: nil},
+		{name: "Success - top-level fields", fields: "id,name", want: fieldSet{"id": nil, "name": nil}},
+		{name: "Success - spaces are trimmed", fields: " id , name ", want: fieldSet{"id": nil, "name": nil}},
+		{name: "Success - nested path", fields: "sprites.front_default", want: fieldSet{"sprites": {"front_default": nil}}},
+		{name: "Success - paths share a parent", fields: "types.type.name,types.slot", want: fieldSet{"types": {"type": {"name": nil}, "slot": nil}}},
+		{name: "Success - whole field then subfield", fields: "sprites,sprites.front_default", want: fieldSet{"sprites": nil}},
+		{name: "Success - subfield then whole field", fields: "sprites.front_default,sprites", want: fieldSet{"sprites": nil}},
+		{name: "Error - trailing comma", fields: "id,", wantErr: true},
+		{name: "Error - empty field", fields: "id,,name", wantErr: true},
+		{name: "Error - trailing dot", fields: "sprites.", wantErr: true},
+		{name: "Error - empty segment", fields: "types..name", wantErr: true},
+		{name: "Error - leading dot", fields: ".id", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := parseFields(fieldsContext(tt.fields))
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestFieldSet_Project(t *testing.T) {
+	body := `{"id":25,"name":"pikachu","weight":60,` +
+		`"types":[{"slot":1,"type":{"name":"electric","url":"https://pokeapi.co/api/v2/type/13/"}}],` +
+		`"sprites":{"front_default":"front.png","back_default":"back.png","other":{"home":{"front_default":"home.png"}}}}`
+
+	tests := []struct {
+		name   string
+		fields string
+		want   string
+	}{
+		{name: "Success - top-level fields", fields: "id,name", want: `{"id":25,"name":"pikachu"}`},
+		{name: "Success - nested path", fields: "sprites.other.home.front_default", want: `{"sprites":{"other":{"home":{"front_default":"home.png"}}}}`},
+		{name: "Success - path through an array", fields: "types.type.name", want: `{"types":[{"type":{"name":"electric"}}]}`},
+		{name: "Success - overlapping selectors keep the whole field", fields: "sprites,sprites.front_default", want: `{"sprites":{"front_default":"front.png","back_default":"back.png","other":{"home":{"front_default":"home.png"}}}}`},
+		{name: "Success - unknown fields are skipped", fields: "id,nickname,sprites.shiny", want: `{"id":25,"sprites":{}}`},
+		{name: "Success - numbers are kept as sent", fields: "weight", want: `{"weight":60}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := parseFields(fieldsContext(tt.fields))
+			require.NoError(t, err)
+
+			projected, err := fields.project([]byte(body))
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(projected))
+		})
+	}
+
+	t.Run("Error - invalid JSON", func(t *testing.T) {
+		_, err := fieldSet{"id": nil}.project([]byte(`{"id":`))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFieldSet_ForList(t *testing.T) {
+	assert.Nil(t, fieldSet(nil).forList())
+
+	fields := fieldSet{"id": nil}
+	list := domain.PokemonSummaryList{Count: 2, Next: "/next", Pokemons: []domain.PokemonSummary{{ID: 1, Name: "bulbasaur", URL: "/api/v1/pokemon/1"}}}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/pokemon", nil)
+
+	respondProjected(c, list, fields.forList(), time.Time{}, "no-cache")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"count":2,"next":"/next","previous":"","pokemons":[{"id":1}]}`, w.Body.String())
+	assert.Equal(t, domain.StrongETag(w.Body.Bytes()), w.Header().Get("ETag"))
+}
+
+func TestPokemonHandler_InvalidFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewPokemonHandler(nil, CachePolicy{}, "")
+	router := gin.New()
+	router.GET("/api/v1/pokemon/:id", handler.GetPokemon)
+	router.GET("/api/v1/pokemon", handler.GetAllPokemon)
+
+	for _, target := range []string{"/api/v1/pokemon/25?fields=id,", "/api/v1/pokemon?fields=types..name"} {
+		t.Run(target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+// ParseBaseURL valida PUBLIC_BASE_URL, la URL con la que los clientes llegan
+// al servicio ("https://api.example.com" o con un prefijo de ruta del
+// balanceador). No se deduce de Host ni de X-Forwarded-Proto porque ambos los
+// controla el cliente. Vacía, los enlaces de la API son solo la ruta.
+func ParseBaseURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+
+	base, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	scheme := strings.ToLower(base.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("scheme must be http or https, got %q", base.Scheme)
+	}
+	if base.Host == "" {
+		return "", errors.New("missing host")
+	}
+	if base.User != nil || base.RawQuery != "" || base.Fragment != "" {
+		return "", errors.New("must not contain credentials, query or fragment")
+	}
+	return scheme + "://" + base.Host + strings.TrimSuffix(base.EscapedPath(), "/"), nil
+}
+
+// pokemonURL enlaza un resumen con su detalle en esta API. El ID sale de la
+// URL de PokeAPI; si no se pudo extraer se usa la ruta por nombre.
+func pokemonURL(base string, summary domain.PokemonSummary) string {
+	if summary.ID > 0 {
+		return fmt.Sprintf("%s/api/v1/pokemon/%d", base, summary.ID)
+	}
+	return base + "/api/v1/pokemon/name/" + url.PathEscape(summary.Name)
+}
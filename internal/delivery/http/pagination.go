@@ -0,0 +1,107 @@
+package delivery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultListLimit = 20
+	// maxListLimit acota el fan-out de una página: cada pokémon expandido que
+	// no esté en caché es una petición a PokeAPI.
+	maxListLimit = 100
+)
+
+// pageCursor es la posición que viaja dentro del token opaco de ?cursor=.
+// Los clientes solo deben copiarlo de next/previous, nunca construirlo.
+type pageCursor struct {
+	Offset int `json:"o"`
+	Limit  int `json:"l"`
+}
+
+func (p pageCursor) encode() string {
+	raw, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(token string) (pageCursor, error) {
+	var cursor pageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, errors.New("invalid cursor")
+	}
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return cursor, errors.New("invalid cursor")
+	}
+	if cursor.Offset < 0 || cursor.Limit < 1 || cursor.Limit > maxListLimit {
+		return cursor, errors.New("invalid cursor")
+	}
+	return cursor, nil
+}
+
+// parsePage lee la página de ?cursor= o de ?offset=; ?limit= puede acompañar
+// a cualquiera de los dos. Un valor inválido es un error en lugar de caer en
+// los valores por defecto, que devolvería otra página sin avisar.
+func parsePage(c *gin.Context) (pageCursor, error) {
+	page := pageCursor{Limit: defaultListLimit}
+
+	if token := c.Query("cursor"); token != "" {
+		if c.Query("offset") != "" {
+			return page, errors.New("cursor and offset cannot be combined")
+		}
+		cursor, err := decodeCursor(token)
+		if err != nil {
+			return page, err
+		}
+		page = cursor
+	}
+
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return page, errors.New("offset must be a non-negative integer")
+		}
+		page.Offset = offset
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return page, fmt.Errorf("limit must be an integer between 1 and %d", maxListLimit)
+		}
+		page.Limit = limit
+	}
+
+	return page, nil
+}
+
+// pageLinks devuelve las URLs de la página siguiente y anterior en esta misma
+// ruta y con el resto de parámetros de la petición, en lugar de las de
+// PokeAPI, precedidas de base (ver ParseBaseURL). Una cadena vacía indica que
+// no hay más páginas en ese sentido.
+func pageLinks(c *gin.Context, base string, page pageCursor, count int) (next, previous string) {
+	if page.Offset+page.Limit < count {
+		next = pageURL(c, base, pageCursor{Offset: page.Offset + page.Limit, Limit: page.Limit})
+	}
+	if page.Offset > 0 {
+		offset := page.Offset - page.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		previous = pageURL(c, base, pageCursor{Offset: offset, Limit: page.Limit})
+	}
+	return next, previous
+}
+
+func pageURL(c *gin.Context, base string, page pageCursor) string {
+	query := c.Request.URL.Query()
+	query.Del("offset")
+	query.Del("limit")
+	query.Set("cursor", page.encode())
+	return base + c.Request.URL.Path + "?" + query.Encode()
+}
@@ -0,0 +1,159 @@
+package delivery
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPageContext(target string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	return c
+}
+
+func TestParsePage(t *testing.T) {
+	cursor := pageCursor{Offset: 40, Limit: 10}.encode()
+
+	tests := []struct {
+		name    string
+		query   string
+		want    pageCursor
+		wantErr bool
+	}{
+		{name: "Success - defaults", query: "", want: pageCursor{Limit: defaultListLimit}},
+		{name: "Success - offset and limit", query: "offset=60&limit=30", want: pageCursor{Offset: 60, Limit: 30}},
+		{name: "Success - offset zero", query: "offset=0", want: pageCursor{Limit: defaultListLimit}},
+		{name: "Success - maximum limit", query: "limit=100", want: pageCursor{Limit: maxListLimit}},
+		{name: "Success - cursor", query: "cursor=" + cursor, want: pageCursor{Offset: 40, Limit: 10}},
+		{name: "Success - cursor with limit", query: "cursor=" + cursor + "&limit=50", want: pageCursor{Offset: 40, Limit: 50}},
+		{name: "Error - cursor with offset", query: "cursor=" + cursor + "&offset=0", wantErr: true},
+		{name: "Error - limit zero", query: "limit=0", wantErr: true},
+		{name: "Error - limit over maximum", query: "limit=101", wantErr: true},
+		{name: "Error - limit not a number", query: "limit=abc", wantErr: true},
+		{name: "Error - negative limit", query: "limit=-5", wantErr: true},
+		{name: "Error - negative offset", query: "offset=-1", wantErr: true},
+		{name: "Error - offset not a number", query: "offset=ten", wantErr: true},
+		{name: "Error - invalid cursor", query: "cursor=not-a-cursor", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := parsePage(newPageContext("/api/v1/pokemon?" + tt.query))
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	raw := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload))
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    pageCursor
+		wantErr bool
+	}{
+		{name: "Success - round trip", token: pageCursor{Offset: 20, Limit: 20}.encode(), want: pageCursor{Offset: 20, Limit: 20}},
+		{name: "Success - first page", token: raw(`{"o":0,"l":1}`), want: pageCursor{Limit: 1}},
+		{name: "Error - not base64", token: "***", wantErr: true},
+		{name: "Error - padded base64", token: base64.URLEncoding.EncodeToString([]byte(`{"o":0,"l":20}`)), wantErr: true},
+		{name: "Error - not JSON", token: raw("offset=20"), wantErr: true},
+		{name: "Error - tampered negative offset", token: raw(`{"o":-20,"l":20}`), wantErr: true},
+		{name: "Error - tampered limit zero", token: raw(`{"o":0,"l":0}`), wantErr: true},
+		{name: "Error - tampered limit over maximum", token: raw(`{"o":0,"l":1000}`), wantErr: true},
+		{name: "Error - missing limit", token: raw(`{"o":20}`), wantErr: true},
+		{name: "Error - wrong types", token: raw(`{"o":"20","l":20}`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := decodeCursor(tt.token)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cursor)
+		})
+	}
+}
+
+func TestPageLinks(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		page         pageCursor
+		count        int
+		wantNext     *pageCursor
+		wantPrevious *pageCursor
+	}{
+		{name: "Success - first page", page: pageCursor{Offset: 0, Limit: 20}, count: 50, wantNext: &pageCursor{Offset: 20, Limit: 20}},
+		{name: "Success - middle page", page: pageCursor{Offset: 20, Limit: 20}, count: 50, wantNext: &pageCursor{Offset: 40, Limit: 20}, wantPrevious: &pageCursor{Offset: 0, Limit: 20}},
+		{name: "Success - last page", page: pageCursor{Offset: 40, Limit: 20}, count: 50, wantPrevious: &pageCursor{Offset: 20, Limit: 20}},
+		{name: "Success - last page fills exactly", page: pageCursor{Offset: 30, Limit: 20}, count: 50, wantPrevious: &pageCursor{Offset: 10, Limit: 20}},
+		{name: "Success - previous clamps to zero", page: pageCursor{Offset: 5, Limit: 20}, count: 50, wantNext: &pageCursor{Offset: 25, Limit: 20}, wantPrevious: &pageCursor{Offset: 0, Limit: 20}},
+		{name: "Success - single page", page: pageCursor{Offset: 0, Limit: 20}, count: 20},
+		{name: "Success - empty list", page: pageCursor{Offset: 0, Limit: 20}, count: 0},
+		{name: "Success - offset past the end", page: pageCursor{Offset: 100, Limit: 20}, count: 50, wantPrevious: &pageCursor{Offset: 80, Limit: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newPageContext("/api/v1/generation/1/pokemon?offset=3&limit=7&lang=es&view=full")
+
+			next, previous := pageLinks(c, "https://api.example.com", tt.page, tt.count)
+
+			assertPageLink(t, tt.wantNext, next)
+			assertPageLink(t, tt.wantPrevious, previous)
+		})
+	}
+}
+
+func assertPageLink(t *testing.T, want *pageCursor, link string) {
+	t.Helper()
+	if want == nil {
+		assert.Empty(t, link)
+		return
+	}
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "https", parsed.Scheme)
+	assert.Equal(t, "api.example.com", parsed.Host)
+	assert.Equal(t, "/api/v1/generation/1/pokemon", parsed.Path)
+
+	query := parsed.Query()
+	assert.Equal(t, "es", query.Get("lang"))
+	assert.Equal(t, "full", query.Get("view"))
+	assert.False(t, query.Has("offset"))
+	assert.False(t, query.Has("limit"))
+
+	cursor, err := decodeCursor(query.Get("cursor"))
+	require.NoError(t, err)
+	assert.Equal(t, *want, cursor)
+}
+
+func TestPageLinks_PathOnly(t *testing.T) {
+	c := newPageContext("/api/v1/pokemon?limit=20")
+
+	next, previous := pageLinks(c, "", pageCursor{Offset: 20, Limit: 20}, 50)
+
+	assert.Equal(t, "/api/v1/pokemon?cursor="+pageCursor{Offset: 40, Limit: 20}.encode(), next)
+	assert.Equal(t, "/api/v1/pokemon?cursor="+pageCursor{Offset: 0, Limit: 20}.encode(), previous)
+}
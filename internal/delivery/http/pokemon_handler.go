@@ -1,8 +1,11 @@
 package delivery
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"reto-pokemon-api/internal/domain"
 
@@ -13,12 +16,18 @@ import (
 type PokemonHandler struct {
 	pokemonUseCase domain.PokemonUseCase
 	validator      *validator.Validate
+	cachePolicy    CachePolicy
+	baseURL        string
 }
 
-func NewPokemonHandler(pokemonUseCase domain.PokemonUseCase) *PokemonHandler {
+// NewPokemonHandler recibe en baseURL el resultado de ParseBaseURL, que se
+// antepone a los enlaces de los listados.
+func NewPokemonHandler(pokemonUseCase domain.PokemonUseCase, cachePolicy CachePolicy, baseURL string) *PokemonHandler {
 	return &PokemonHandler{
 		pokemonUseCase: pokemonUseCase,
 		validator:      validator.New(),
+		cachePolicy:    cachePolicy,
+		baseURL:        baseURL,
 	}
 }
 
@@ -33,39 +42,70 @@ func (h *PokemonHandler) HealthCheck(c *gin.Context) {
 func (h *PokemonHandler) GetPokemon(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		h.sendError(c, http.StatusBadRequest, "Pokemon ID is required", nil)
+		sendError(c, http.StatusBadRequest, "Pokemon ID is required", nil)
+		return
+	}
+
+	fields, err := parseFields(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid fields", err)
 		return
 	}
 
 	pokemon, err := h.pokemonUseCase.GetPokemonByID(id)
 	if err != nil {
-		h.handleError(c, err)
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, pokemon)
+	if lang := requestLanguage(c); lang != "" {
+		pokemon = h.pokemonUseCase.LocalizePokemon(pokemon, lang)
+		setContentLanguage(c, pokemon.Language)
+	}
+
+	pokemon.Sprites = pokemon.Sprites.SelectSprites(spriteSections(c))
+	respondProjected(c, pokemon, fields, pokemon.UpdatedAt, h.cachePolicy.cacheControl(false))
 }
 
 func (h *PokemonHandler) GetPokemonByName(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		h.sendError(c, http.StatusBadRequest, "Pokemon name is required", nil)
+		sendError(c, http.StatusBadRequest, "Pokemon name is required", nil)
+		return
+	}
+
+	fields, err := parseFields(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid fields", err)
 		return
 	}
 
 	pokemon, err := h.pokemonUseCase.GetPokemonByName(name)
 	if err != nil {
-		h.handleError(c, err)
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, pokemon)
+	if lang := requestLanguage(c); lang != "" {
+		pokemon = h.pokemonUseCase.LocalizePokemon(pokemon, lang)
+		setContentLanguage(c, pokemon.Language)
+	}
+
+	pokemon.Sprites = pokemon.Sprites.SelectSprites(spriteSections(c))
+	respondProjected(c, pokemon, fields, pokemon.UpdatedAt, h.cachePolicy.cacheControl(false))
 }
 
 func (h *PokemonHandler) GetAllPokemon(c *gin.Context) {
+	page, err := parsePage(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid pagination", err)
+		return
+	}
+
 	filter := domain.PokemonFilter{
-		Limit:  h.parseIntQuery(c, "limit", 0),
-		Offset: h.parseIntQuery(c, "offset", 0),
+		Ability: c.Query("ability"),
+		Limit:   page.Limit,
+		Offset:  page.Offset,
 	}
 	
 	if isFavoriteStr := c.Query("is_favorite"); isFavoriteStr != "" {
@@ -74,38 +114,474 @@ func (h *PokemonHandler) GetAllPokemon(c *gin.Context) {
 		}
 	}
 	
+	fields, err := parseFields(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid fields", err)
+		return
+	}
+
+	view, err := parseView(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid view", err)
+		return
+	}
+	if view == viewSummary {
+		h.getPokemonSummaries(c, filter, page, fields)
+		return
+	}
+
 	pokemon, err := h.pokemonUseCase.GetPokemonAll(filter)
 	if err != nil {
-		h.handleError(c, err)
+		handleError(c, err)
+		return
+	}
+
+	h.respondPokemonList(c, pokemon, page, fields)
+}
+
+// getPokemonSummaries atiende ?view=summary y ?expand=false: id, nombre y
+// enlace al detalle, sin pedir cada pokémon de la página a PokeAPI.
+func (h *PokemonHandler) getPokemonSummaries(c *gin.Context, filter domain.PokemonFilter, page pageCursor, fields fieldSet) {
+	summaries, err := h.pokemonUseCase.GetPokemonSummaries(filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	for i := range summaries.Pokemons {
+		summaries.Pokemons[i].URL = pokemonURL(h.baseURL, summaries.Pokemons[i])
+	}
+	summaries.Next, summaries.Previous = pageLinks(c, h.baseURL, page, summaries.Count)
+
+	respondProjected(c, summaries, fields.forList(), time.Time{}, h.cachePolicy.cacheControl(true))
+}
+
+func (h *PokemonHandler) GetPokemonStats(c *gin.Context) {
+	req := domain.StatsRequest{
+		Level:  50,
+		Nature: c.DefaultQuery("nature", "hardy"),
+		IVs:    [6]int{domain.MaxIV, domain.MaxIV, domain.MaxIV, domain.MaxIV, domain.MaxIV, domain.MaxIV},
+	}
+
+	if level := c.Query("level"); level != "" {
+		parsed, err := strconv.Atoi(level)
+		if err != nil {
+			sendError(c, http.StatusBadRequest, "Invalid level", err)
+			return
+		}
+		req.Level = parsed
+	}
+
+	var err error
+	if req.IVs, err = parseStatSpread(c.Query("ivs"), req.IVs); err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid IVs", err)
+		return
+	}
+	if req.EVs, err = parseStatSpread(c.Query("evs"), req.EVs); err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid EVs", err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid stat parameters", err)
+		return
+	}
+
+	lang := requestLanguage(c)
+	stats, err := h.pokemonUseCase.GetPokemonStats(c.Param("id"), req, lang)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, stats.Language)
+
+	respondJSON(c, stats, stats.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) ComparePokemon(c *gin.Context) {
+	var req struct {
+		IDs []string `validate:"min=2,max=6,unique,dive,required"`
+	}
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		req.IDs = append(req.IDs, strings.ToLower(strings.TrimSpace(id)))
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sendError(c, http.StatusBadRequest, "ids must list between 2 and 6 different pokemon", err)
+		return
+	}
+
+	lang := requestLanguage(c)
+	comparison, err := h.pokemonUseCase.ComparePokemon(req.IDs, lang)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, comparison.Language)
+
+	respondJSON(c, comparison, comparison.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) GetPokemonMoves(c *gin.Context) {
+	filter := domain.MoveFilter{
+		LearnMethod:  c.Query("learn_method"),
+		VersionGroup: c.Query("version_group"),
+	}
+
+	lang := requestLanguage(c)
+	moves, err := h.pokemonUseCase.GetPokemonMoves(c.Param("id"), filter, lang)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, moves.Language)
+
+	respondJSON(c, moves, moves.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) GetPokemonEncounters(c *gin.Context) {
+	filter := domain.EncounterFilter{
+		Version: c.Query("version"),
+	}
+
+	lang := requestLanguage(c)
+	encounters, err := h.pokemonUseCase.GetPokemonEncounters(c.Param("id"), filter, lang)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, encounters.Language)
+
+	respondJSON(c, encounters, encounters.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) GetPokemonForms(c *gin.Context) {
+	lang := requestLanguage(c)
+	forms, err := h.pokemonUseCase.GetPokemonForms(c.Param("id"), lang)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, forms.Language)
+
+	sections := spriteSections(c)
+	for i := range forms.Varieties {
+		forms.Varieties[i].Sprites = forms.Varieties[i].Sprites.SelectSprites(sections)
+	}
+	respondJSON(c, forms, forms.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) GetPokemonSprite(c *gin.Context) {
+	var req struct {
+		Size   int    `validate:"omitempty,min=16,max=512"`
+		Format string `validate:"omitempty,oneof=png webp"`
+	}
+	req.Format = strings.ToLower(c.Query("format"))
+	if size := c.Query("size"); size != "" {
+		parsed, err := strconv.Atoi(size)
+		if err != nil {
+			sendError(c, http.StatusBadRequest, "Invalid size", err)
+			return
+		}
+		req.Size = parsed
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid size or format", err)
+		return
+	}
+
+	img, err := h.pokemonUseCase.GetPokemonSprite(c.Param("id"), c.Param("kind"), req.Size, req.Format)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	setValidators(c, img.ETag, img.LastModified, "public, max-age=86400")
+	if notModified(c, img.ETag, img.LastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, img.ContentType, img.Data)
+}
+
+func (h *PokemonHandler) GetMove(c *gin.Context) {
+	move, err := h.pokemonUseCase.GetMove(c.Param("name"), requestLanguage(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, move.Language)
+
+	respondJSON(c, move, move.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) GetAbility(c *gin.Context) {
+	ability, err := h.pokemonUseCase.GetAbility(c.Param("name"), requestLanguage(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, ability.Language)
+
+	respondJSON(c, ability, ability.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) GetItem(c *gin.Context) {
+	item, err := h.pokemonUseCase.GetItem(c.Param("name"), requestLanguage(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, item.Language)
+
+	respondJSON(c, item, item.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) GetBerry(c *gin.Context) {
+	berry, err := h.pokemonUseCase.GetBerry(c.Param("name"), requestLanguage(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+	setContentLanguage(c, berry.Language)
+
+	respondJSON(c, berry, berry.UpdatedAt, h.cachePolicy.cacheControl(false))
+}
+
+func (h *PokemonHandler) GetPokemonByGeneration(c *gin.Context) {
+	page, err := parsePage(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid pagination", err)
+		return
+	}
+
+	filter := domain.PokemonFilter{
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+
+	fields, err := parseFields(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid fields", err)
+		return
+	}
+
+	if err := requireFullView(c); err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid view", err)
+		return
+	}
+
+	pokemon, err := h.pokemonUseCase.GetPokemonByGeneration(c.Param("id"), filter)
+	if err != nil {
+		handleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, pokemon)
+	h.respondPokemonList(c, pokemon, page, fields)
 }
 
-func (h *PokemonHandler) parseIntQuery(c *gin.Context, key string, defaultValue int) int {
-	if value := c.Query(key); value != "" {
-		if parsed, err := strconv.Atoi(value); err == nil {
-			return parsed
+func (h *PokemonHandler) GetPokemonByPokedex(c *gin.Context) {
+	page, err := parsePage(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid pagination", err)
+		return
+	}
+
+	filter := domain.PokemonFilter{
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+
+	fields, err := parseFields(c)
+	if err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid fields", err)
+		return
+	}
+
+	if err := requireFullView(c); err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid view", err)
+		return
+	}
+
+	pokemon, err := h.pokemonUseCase.GetPokemonByPokedex(c.Param("name"), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	h.respondPokemonList(c, pokemon, page, fields)
+}
+
+// parseStatSpread acepta un único valor para los seis stats o seis valores
+// separados por comas en el orden hp,atk,def,spa,spd,spe.
+func parseStatSpread(value string, defaultValue [6]int) ([6]int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	var spread [6]int
+	parts := strings.Split(value, ",")
+	if len(parts) != 1 && len(parts) != len(spread) {
+		return spread, fmt.Errorf("expected 1 or %d comma-separated values, got %d", len(spread), len(parts))
+	}
+
+	for i := range spread {
+		part := parts[0]
+		if len(parts) > 1 {
+			part = parts[i]
 		}
+		parsed, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return spread, err
+		}
+		spread[i] = parsed
+	}
+	return spread, nil
+}
+
+// requestLanguage devuelve, como código de PokeAPI, el idioma de ?lang= o,
+// si no viene, la etiqueta con mayor q de Accept-Language que PokeAPI tenga.
+// Un ?lang= desconocido se sirve en inglés; una cadena vacía significa sin
+// localizar.
+func requestLanguage(c *gin.Context) string {
+	if lang := c.Query("lang"); lang != "" {
+		if normalized, ok := domain.NormalizeLanguage(lang); ok {
+			return normalized
+		}
+		return domain.DefaultLanguage
+	}
+
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, supported := domain.NormalizeLanguage(tag)
+		if !supported {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// setContentLanguage anuncia el idioma en que se sirvió la respuesta, que
+// puede no ser el pedido si faltaba la traducción.
+func setContentLanguage(c *gin.Context, lang string) {
+	if lang != "" {
+		c.Header("Content-Language", lang)
+	}
+}
+
+const (
+	viewFull    = "full"
+	viewSummary = "summary"
+)
+
+// parseView lee ?view= y su alias ?expand= (true es full y false, summary).
+// Si vienen los dos deben coincidir: expand=false&view=full es un error en
+// lugar de que uno gane en silencio.
+func parseView(c *gin.Context) (string, error) {
+	view := c.Query("view")
+	if view != "" && view != viewFull && view != viewSummary {
+		return "", fmt.Errorf("unknown view %q, expected full or summary", view)
+	}
+
+	if value := c.Query("expand"); value != "" {
+		expand, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("expand must be true or false, got %q", value)
+		}
+		expanded := viewSummary
+		if expand {
+			expanded = viewFull
+		}
+		if view != "" && view != expanded {
+			return "", fmt.Errorf("expand=%s conflicts with view=%s", value, view)
+		}
+		view = expanded
+	}
+
+	if view == "" {
+		view = viewFull
+	}
+	return view, nil
+}
+
+// requireFullView valida view/expand en los listados que solo tienen la vista
+// completa (generación y Pokédex): view=summary o expand=false es un error en
+// lugar de ignorarse y devolver el detalle de cada Pokemon.
+func requireFullView(c *gin.Context) error {
+	view, err := parseView(c)
+	if err != nil {
+		return err
+	}
+	if view != viewFull {
+		return fmt.Errorf("view=%s is only supported on /api/v1/pokemon", view)
+	}
+	return nil
+}
+
+// spriteSections lee ?sprites=official-artwork,home. Sin el parámetro solo se
+// devuelven los cuatro sprites clásicos, como antes de modelar el árbol completo.
+func spriteSections(c *gin.Context) []string {
+	if value := c.Query("sprites"); value != "" {
+		return strings.Split(value, ",")
 	}
-	return defaultValue
+	return nil
 }
 
-func (h *PokemonHandler) handleError(c *gin.Context, err error) {
+func handleError(c *gin.Context, err error) {
 	switch err {
 	case domain.ErrPokemonNotFound:
-		h.sendError(c, http.StatusNotFound, "Pokemon not found", err)
+		sendError(c, http.StatusNotFound, "Pokemon not found", err)
+	case domain.ErrMoveNotFound:
+		sendError(c, http.StatusNotFound, "Move not found", err)
+	case domain.ErrAbilityNotFound:
+		sendError(c, http.StatusNotFound, "Ability not found", err)
+	case domain.ErrItemNotFound:
+		sendError(c, http.StatusNotFound, "Item not found", err)
+	case domain.ErrBerryNotFound:
+		sendError(c, http.StatusNotFound, "Berry not found", err)
+	case domain.ErrGenerationNotFound:
+		sendError(c, http.StatusNotFound, "Generation not found", err)
+	case domain.ErrPokedexNotFound:
+		sendError(c, http.StatusNotFound, "Pokedex not found", err)
+	case domain.ErrSpriteNotFound:
+		sendError(c, http.StatusNotFound, "Sprite not found", err)
+	case domain.ErrTypeNotFound:
+		sendError(c, http.StatusNotFound, "Type not found", err)
 	case domain.ErrInvalidPokemonData:
-		h.sendError(c, http.StatusBadRequest, "Invalid pokemon data", err)
+		sendError(c, http.StatusBadRequest, "Invalid pokemon data", err)
 	case domain.ErrPokeAPIUnavailable:
-		h.sendError(c, http.StatusServiceUnavailable, "PokeAPI service unavailable", err)
+		sendError(c, http.StatusServiceUnavailable, "PokeAPI service unavailable", err)
+	case domain.ErrTeamNotFound:
+		sendError(c, http.StatusNotFound, "Team not found", err)
+	case domain.ErrInvalidStatParams:
+		sendError(c, http.StatusBadRequest, "Invalid stat parameters", err)
+	case domain.ErrInvalidTeamData:
+		sendError(c, http.StatusBadRequest, "Invalid team data", err)
+	case domain.ErrUnauthorized:
+		sendError(c, http.StatusUnauthorized, "Unauthorized", err)
+	case domain.ErrForbidden:
+		sendError(c, http.StatusForbidden, "Forbidden", err)
 	default:
-		h.sendError(c, http.StatusInternalServerError, "Internal server error", err)
+		sendError(c, http.StatusInternalServerError, "Internal server error", err)
 	}
 }
 
-func (h *PokemonHandler) sendError(c *gin.Context, code int, message string, err error) {
+func sendError(c *gin.Context, code int, message string, err error) {
 	errorMsg := message
 	if err != nil {
 		errorMsg = err.Error()
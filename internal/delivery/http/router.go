@@ -4,7 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(pokemonHandler *PokemonHandler) *gin.Engine {
+func SetupRoutes(pokemonHandler *PokemonHandler, teamHandler *TeamHandler) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.New()
@@ -12,6 +12,7 @@ func SetupRoutes(pokemonHandler *PokemonHandler) *gin.Engine {
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(CORSMiddleware())
+	router.Use(CompressionMiddleware())
 
 	router.GET("/health", pokemonHandler.HealthCheck)
 
@@ -20,9 +21,32 @@ func SetupRoutes(pokemonHandler *PokemonHandler) *gin.Engine {
 		pokemon := v1.Group("/pokemon")
 		{
 			pokemon.GET("", pokemonHandler.GetAllPokemon)
+			pokemon.GET("/compare", pokemonHandler.ComparePokemon)
 			pokemon.GET("/:id", pokemonHandler.GetPokemon)
+			pokemon.GET("/:id/stats", pokemonHandler.GetPokemonStats)
+			pokemon.GET("/:id/moves", pokemonHandler.GetPokemonMoves)
+			pokemon.GET("/:id/encounters", pokemonHandler.GetPokemonEncounters)
+			pokemon.GET("/:id/forms", pokemonHandler.GetPokemonForms)
+			pokemon.GET("/:id/sprite/:kind", pokemonHandler.GetPokemonSprite)
 			pokemon.GET("/name/:name", pokemonHandler.GetPokemonByName)
 		}
+
+		v1.GET("/generations/:id/pokemon", pokemonHandler.GetPokemonByGeneration)
+		v1.GET("/pokedex/:name", pokemonHandler.GetPokemonByPokedex)
+		v1.GET("/moves/:name", pokemonHandler.GetMove)
+		v1.GET("/abilities/:name", pokemonHandler.GetAbility)
+		v1.GET("/items/:name", pokemonHandler.GetItem)
+		v1.GET("/berries/:name", pokemonHandler.GetBerry)
+
+		teams := v1.Group("/teams")
+		{
+			teams.GET("", teamHandler.ListTeams)
+			teams.POST("", teamHandler.CreateTeam)
+			teams.GET("/:id", teamHandler.GetTeam)
+			teams.PUT("/:id", teamHandler.UpdateTeam)
+			teams.DELETE("/:id", teamHandler.DeleteTeam)
+			teams.GET("/:id/analysis", teamHandler.AnalyzeTeam)
+		}
 	}
 
 	return router
@@ -32,7 +56,8 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, X-User-ID, If-None-Match, If-Modified-Since")
+		c.Header("Access-Control-Expose-Headers", "ETag, Last-Modified, Content-Language")
 		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
@@ -0,0 +1,114 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// encodeList codifica un listado llamando a encode una sola vez por elemento:
+// una página puede tener cientos de pokémon totalmente expandidos, así que no
+// se construye una copia localizada de la página, sino que cada elemento se
+// prepara y se escribe directamente en el cuerpo. El ETag se calcula después
+// sobre esos mismos bytes, que son los que se envían.
+func encodeList[T any](envelope interface{}, key string, items []T, encode func(T) ([]byte, error)) ([]byte, error) {
+	var body bytes.Buffer
+	if err := writeList(&body, envelope, key, items, encode); err != nil {
+		return nil, err
+	}
+	return body.Bytes(), nil
+}
+
+// writeList escribe envelope con items en el lugar de su campo key, que debe
+// estar a nil en envelope. Con encode = json.Marshal el resultado es byte a
+// byte el de json.Marshal sobre la lista completa, con los campos en su
+// orden; si items es nil se escribe tal cual, con key a null.
+func writeList[T any](w io.Writer, envelope interface{}, key string, items []T, encode func(T) ([]byte, error)) error {
+	raw, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	if items == nil {
+		_, err := w.Write(raw)
+		return err
+	}
+
+	// Dentro de un string las comillas van escapadas, así que esta secuencia
+	// solo puede ser el propio campo.
+	placeholder := []byte(`"` + key + `":null`)
+	at := bytes.Index(raw, placeholder)
+	if at < 0 {
+		return fmt.Errorf("list field %q is not null in the envelope", key)
+	}
+	valueAt := at + len(placeholder) - len("null")
+
+	if _, err := w.Write(raw[:valueAt]); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i, item := range items {
+		encoded, err := encode(item)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(encoded); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, "]"); err != nil {
+		return err
+	}
+	_, err = w.Write(raw[at+len(placeholder):])
+	return err
+}
+
+// respondPokemonList envía una página de pokémon completos. Cada uno se
+// localiza, se queda con los sprites elegidos y se proyecta con ?fields= al
+// escribirlo, sin modificar la página que devolvió el caso de uso.
+func (h *PokemonHandler) respondPokemonList(c *gin.Context, list *domain.PokemonList, page pageCursor, fields fieldSet) {
+	lang := requestLanguage(c)
+	served := domain.NewServedLanguages(lang)
+	sections := spriteSections(c)
+
+	envelope := *list
+	envelope.Pokemons = nil
+	envelope.Next, envelope.Previous = pageLinks(c, h.baseURL, page, list.Count)
+
+	var items []domain.Pokemon
+	if list.Pokemons != nil {
+		items = *list.Pokemons
+	}
+
+	encode := func(p domain.Pokemon) ([]byte, error) {
+		if lang != "" {
+			p = *h.pokemonUseCase.LocalizePokemon(&p, lang)
+			served.Add(p.Language)
+		}
+		p.Sprites = p.Sprites.SelectSprites(sections)
+		item, err := json.Marshal(p)
+		if err == nil && fields != nil {
+			item, err = fields.project(item)
+		}
+		return item, err
+	}
+	body, err := encodeList(envelope, "pokemons", items, encode)
+	if err != nil {
+		sendError(c, http.StatusInternalServerError, "Failed to encode response", err)
+		return
+	}
+	setContentLanguage(c, served.String())
+	respondBody(c, body, listModified(list), h.cachePolicy.cacheControl(true))
+}
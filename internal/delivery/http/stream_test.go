@@ -0,0 +1,180 @@
+package delivery
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func marshalItem[T any](item T) ([]byte, error) {
+	return json.Marshal(item)
+}
+
+func TestWriteList_MatchesMarshal(t *testing.T) {
+	pokemons := []domain.Pokemon{
+		{ID: 25, Name: "pikachu", Height: 4, Weight: 60},
+		{ID: 26, Name: "raichu", Height: 8, Weight: 300},
+	}
+	empty := []domain.Pokemon{}
+
+	tests := []struct {
+		name string
+		list domain.PokemonList
+	}{
+		{name: "Success - items", list: domain.PokemonList{Count: 1302, Next: "/next?a=\"pokemons\":null", Pokemons: &pokemons}},
+		{name: "Success - empty page", list: domain.PokemonList{Count: 0, Pokemons: &empty}},
+		{name: "Success - nil page", list: domain.PokemonList{Count: 3, Previous: "/previous"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := json.Marshal(tt.list)
+			require.NoError(t, err)
+
+			envelope := tt.list
+			envelope.Pokemons = nil
+			var items []domain.Pokemon
+			if tt.list.Pokemons != nil {
+				items = *tt.list.Pokemons
+			}
+			var buf bytes.Buffer
+
+			err = writeList(&buf, envelope, "pokemons", items, marshalItem[domain.Pokemon])
+
+			require.NoError(t, err)
+			assert.Equal(t, string(want), buf.String())
+		})
+	}
+
+	t.Run("Success - summary list", func(t *testing.T) {
+		list := domain.PokemonSummaryList{Count: 2, Pokemons: []domain.PokemonSummary{{ID: 1, Name: "bulbasaur"}, {Name: "mr-mime"}}}
+		want, err := json.Marshal(list)
+		require.NoError(t, err)
+
+		envelope := list
+		envelope.Pokemons = nil
+		var buf bytes.Buffer
+
+		err = writeList(&buf, envelope, "pokemons", list.Pokemons, marshalItem[domain.PokemonSummary])
+
+		require.NoError(t, err)
+		assert.Equal(t, string(want), buf.String())
+	})
+}
+
+func TestWriteList_Errors(t *testing.T) {
+	items := []domain.PokemonSummary{{ID: 1, Name: "bulbasaur"}}
+
+	t.Run("Error - list field is not null", func(t *testing.T) {
+		envelope := domain.PokemonSummaryList{Pokemons: items}
+
+		err := writeList(&bytes.Buffer{}, envelope, "pokemons", items, marshalItem[domain.PokemonSummary])
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - unknown field", func(t *testing.T) {
+		err := writeList(&bytes.Buffer{}, domain.PokemonSummaryList{}, "results", items, marshalItem[domain.PokemonSummary])
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Error - encode fails", func(t *testing.T) {
+		encodeErr := errors.New("boom")
+		encode := func(domain.PokemonSummary) ([]byte, error) { return nil, encodeErr }
+
+		err := writeList(&bytes.Buffer{}, domain.PokemonSummaryList{}, "pokemons", items, encode)
+
+		assert.ErrorIs(t, err, encodeErr)
+	})
+}
+
+// localizeUseCase solo implementa LocalizePokemon y cuenta las llamadas.
+type localizeUseCase struct {
+	domain.PokemonUseCase
+	calls atomic.Int32
+}
+
+func (u *localizeUseCase) LocalizePokemon(pokemon *domain.Pokemon, lang string) *domain.Pokemon {
+	u.calls.Add(1)
+	localized := *pokemon
+	localized.LocalizedName = strings.ToUpper(pokemon.Name)
+	localized.Language = lang
+	// raichu no tiene traducción y cae a inglés.
+	if pokemon.Name == "raichu" {
+		localized.Language = domain.DefaultLanguage
+	}
+	return &localized
+}
+
+func TestPokemonHandler_RespondPokemonList_EncodesOnce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pokemons := []domain.Pokemon{{ID: 25, Name: "pikachu"}, {ID: 26, Name: "raichu"}}
+	modified := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	pokemons[1].UpdatedAt = modified
+	list := &domain.PokemonList{Count: 2, Pokemons: &pokemons}
+	useCase := &localizeUseCase{}
+	handler := NewPokemonHandler(useCase, CachePolicy{}, "")
+
+	router := gin.New()
+	router.GET("/pokemon", func(c *gin.Context) {
+		handler.respondPokemonList(c, list, pageCursor{Limit: 20}, nil)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pokemon?lang=es", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int32(len(pokemons)), useCase.calls.Load())
+	assert.Equal(t, domain.StrongETag(w.Body.Bytes()), w.Header().Get("ETag"))
+	assert.Equal(t, modified.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "es, en", w.Header().Get("Content-Language"))
+	var body domain.PokemonList
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "RAICHU", (*body.Pokemons)[1].LocalizedName)
+	assert.Empty(t, pokemons[1].LocalizedName, "the use case page must not be modified")
+
+	req := httptest.NewRequest(http.MethodGet, "/pokemon?lang=es", nil)
+	req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.Bytes())
+}
+
+func TestPokemonHandler_RespondPokemonList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pokemons := []domain.Pokemon{
+		{ID: 25, Name: "pikachu", Sprites: domain.Sprite{FrontDefault: "front.png", FrontFemale: "female.png"}},
+	}
+	list := &domain.PokemonList{Count: 1, Pokemons: &pokemons}
+	handler := NewPokemonHandler(nil, CachePolicy{}, "")
+
+	router := gin.New()
+	router.GET("/pokemon", func(c *gin.Context) {
+		fields, err := parseFields(c)
+		require.NoError(t, err)
+		handler.respondPokemonList(c, list, pageCursor{Limit: 20}, fields)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pokemon?fields=id,sprites", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"count":1,"next":"","previous":"","pokemons":[{"id":25,"sprites":{"front_default":"front.png","front_shiny":"","back_default":"","back_shiny":""}}]}`, w.Body.String())
+	assert.Equal(t, "female.png", (*list.Pokemons)[0].Sprites.FrontFemale, "the use case page must not be modified")
+}
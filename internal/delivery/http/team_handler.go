@@ -0,0 +1,117 @@
+package delivery
+
+import (
+	"net/http"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// UserIDHeader identifica al dueño de los equipos en cada petición.
+const UserIDHeader = "X-User-ID"
+
+type TeamHandler struct {
+	teamUseCase domain.TeamUseCase
+	validator   *validator.Validate
+}
+
+func NewTeamHandler(teamUseCase domain.TeamUseCase) *TeamHandler {
+	return &TeamHandler{
+		teamUseCase: teamUseCase,
+		validator:   validator.New(),
+	}
+}
+
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	teams, err := h.teamUseCase.ListTeams(c.GetHeader(UserIDHeader))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	respondPrivate(c, teams, time.Time{})
+}
+
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	team, ok := h.bindTeam(c)
+	if !ok {
+		return
+	}
+
+	created, err := h.teamUseCase.CreateTeam(c.GetHeader(UserIDHeader), team)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	team, err := h.teamUseCase.GetTeam(c.GetHeader(UserIDHeader), c.Param("id"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	respondPrivate(c, team, team.UpdatedAt)
+}
+
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	team, ok := h.bindTeam(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.teamUseCase.UpdateTeam(c.GetHeader(UserIDHeader), c.Param("id"), team)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	if err := h.teamUseCase.DeleteTeam(c.GetHeader(UserIDHeader), c.Param("id")); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TeamHandler) AnalyzeTeam(c *gin.Context) {
+	analysis, err := h.teamUseCase.AnalyzeTeam(c.GetHeader(UserIDHeader), c.Param("id"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	respondPrivate(c, analysis, time.Time{})
+}
+
+// respondPrivate envía un recurso del usuario: admite peticiones
+// condicionales, pero cualquier caché debe revalidarlo y separarlo por usuario.
+func respondPrivate(c *gin.Context, value interface{}, lastModified time.Time) {
+	c.Writer.Header().Add("Vary", UserIDHeader)
+	respondJSON(c, value, lastModified, privateNoCache)
+}
+
+func (h *TeamHandler) bindTeam(c *gin.Context) (*domain.Team, bool) {
+	var team domain.Team
+	if err := c.ShouldBindJSON(&team); err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid request body", err)
+		return nil, false
+	}
+
+	if err := h.validator.Struct(&team); err != nil {
+		sendError(c, http.StatusBadRequest, "Invalid team data", err)
+		return nil, false
+	}
+
+	return &team, true
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type AbilityDetail struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Effect        string           `json:"effect"`
+	ShortEffect   string           `json:"short_effect"`
+	Generation    string           `json:"generation"`
+	Pokemon       []AbilityPokemon `json:"pokemon"`
+	Names         LocalizedStrings `json:"names,omitempty"`
+	FlavorTexts   LocalizedStrings `json:"flavor_texts,omitempty"`
+	Language      string           `json:"language,omitempty"`
+	LocalizedName string           `json:"localized_name,omitempty"`
+	FlavorText    string           `json:"flavor_text,omitempty"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+type AbilityPokemon struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	IsHidden bool   `json:"is_hidden"`
+	Slot     int    `json:"slot"`
+}
@@ -0,0 +1,151 @@
+package domain
+
+import "time"
+
+const (
+	MinComparePokemon = 2
+	MaxComparePokemon = 6
+)
+
+type PokemonComparison struct {
+	Pokemon []ComparedPokemon `json:"pokemon"`
+	Fields  []ComparisonField `json:"fields"`
+	Traits  []ComparisonTrait `json:"traits"`
+	// Language son los idiomas de los pokémon y de las stats traducidas.
+	Language string `json:"language,omitempty"`
+	// UpdatedAt es la obtención más reciente de los pokémon comparados; solo
+	// alimenta Last-Modified.
+	UpdatedAt time.Time `json:"-"`
+}
+
+type ComparedPokemon struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	LocalizedName string    `json:"localized_name,omitempty"`
+	Varieties     []Variety `json:"varieties,omitempty"`
+}
+
+// ComparisonField es una fila numérica de la tabla: un valor por Pokémon en el
+// mismo orden que PokemonComparison.Pokemon, la diferencia de cada uno
+// respecto al mejor valor y los IDs de los ganadores (varios en caso de
+// empate). HigherIsBetter indica qué se considera mejor en esa fila.
+type ComparisonField struct {
+	Field          string `json:"field"`
+	LocalizedField string `json:"localized_field,omitempty"`
+	HigherIsBetter bool   `json:"higher_is_better"`
+	Values         []int  `json:"values"`
+	Deltas         []int  `json:"deltas"`
+	Winners        []int  `json:"winners"`
+}
+
+// ComparisonTrait es una fila de conjuntos (tipos o habilidades): los valores
+// de cada Pokémon y los que comparten todos.
+type ComparisonTrait struct {
+	Field  string     `json:"field"`
+	Values [][]string `json:"values"`
+	Shared []string   `json:"shared"`
+}
+
+// ComparePokemon construye la tabla comparativa. Todas las filas numéricas
+// tienen ganador y diferencias; en todas gana el valor más alto.
+func ComparePokemon(pokemons []*Pokemon) *PokemonComparison {
+	comparison := &PokemonComparison{
+		Pokemon: make([]ComparedPokemon, len(pokemons)),
+	}
+
+	for i, p := range pokemons {
+		comparison.Pokemon[i] = ComparedPokemon{
+			ID:            p.ID,
+			Name:          p.Name,
+			LocalizedName: p.LocalizedName,
+			Varieties:     p.Varieties,
+		}
+	}
+
+	row := func(field string, higherIsBetter bool, value func(p *Pokemon) int) {
+		comparison.Fields = append(comparison.Fields, compareField(field, pokemons, higherIsBetter, value))
+	}
+
+	row("height", true, func(p *Pokemon) int { return p.Height })
+	row("weight", true, func(p *Pokemon) int { return p.Weight })
+	row("base_experience", true, func(p *Pokemon) int { return p.BaseExp })
+	for _, name := range StatNames {
+		stat := name
+		row(stat, true, func(p *Pokemon) int { return p.BaseStatValue(stat) })
+	}
+	row("stat_total", true, func(p *Pokemon) int { return p.StatTotal() })
+
+	comparison.Traits = []ComparisonTrait{
+		compareTrait("types", pokemons, func(p *Pokemon) []string { return p.TypeNames() }),
+		compareTrait("abilities", pokemons, func(p *Pokemon) []string {
+			names := make([]string, len(p.Abilities))
+			for i, a := range p.Abilities {
+				names[i] = a.Ability.Name
+			}
+			return names
+		}),
+	}
+
+	return comparison
+}
+
+// compareField calcula los valores de una fila, su diferencia con el mejor
+// (negativa si higherIsBetter, positiva si no) y los ganadores.
+func compareField(field string, pokemons []*Pokemon, higherIsBetter bool, value func(p *Pokemon) int) ComparisonField {
+	result := ComparisonField{
+		Field:          field,
+		HigherIsBetter: higherIsBetter,
+		Values:         make([]int, len(pokemons)),
+		Deltas:         make([]int, len(pokemons)),
+		Winners:        []int{},
+	}
+
+	best := 0
+	for i, p := range pokemons {
+		result.Values[i] = value(p)
+		if i == 0 || (higherIsBetter && result.Values[i] > best) || (!higherIsBetter && result.Values[i] < best) {
+			best = result.Values[i]
+		}
+	}
+
+	for i, p := range pokemons {
+		result.Deltas[i] = result.Values[i] - best
+		if result.Values[i] == best {
+			result.Winners = append(result.Winners, p.ID)
+		}
+	}
+	return result
+}
+
+func compareTrait(field string, pokemons []*Pokemon, values func(p *Pokemon) []string) ComparisonTrait {
+	result := ComparisonTrait{
+		Field:  field,
+		Values: make([][]string, len(pokemons)),
+		Shared: []string{},
+	}
+
+	counts := map[string]int{}
+	for i, p := range pokemons {
+		result.Values[i] = values(p)
+		seen := map[string]bool{}
+		for _, value := range result.Values[i] {
+			if !seen[value] {
+				seen[value] = true
+				counts[value]++
+			}
+		}
+	}
+
+	if len(pokemons) == 0 {
+		return result
+	}
+
+	// Se conserva el orden del primer Pokémon.
+	for _, value := range result.Values[0] {
+		if counts[value] == len(pokemons) {
+			result.Shared = append(result.Shared, value)
+			counts[value] = 0
+		}
+	}
+	return result
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareField(t *testing.T) {
+	pokemons := []*Pokemon{{ID: 1, Weight: 69}, {ID: 4, Weight: 85}, {ID: 7, Weight: 69}}
+	weight := func(p *Pokemon) int { return p.Weight }
+
+	tests := []struct {
+		name           string
+		higherIsBetter bool
+		deltas         []int
+		winners        []int
+	}{
+		{name: "Success - higher is better", higherIsBetter: true, deltas: []int{-16, 0, -16}, winners: []int{4}},
+		{name: "Success - lower is better with a tie", higherIsBetter: false, deltas: []int{0, 16, 0}, winners: []int{1, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := compareField("weight", pokemons, tt.higherIsBetter, weight)
+
+			assert.Equal(t, "weight", field.Field)
+			assert.Equal(t, tt.higherIsBetter, field.HigherIsBetter)
+			assert.Equal(t, []int{69, 85, 69}, field.Values)
+			assert.Equal(t, tt.deltas, field.Deltas)
+			assert.Equal(t, tt.winners, field.Winners)
+		})
+	}
+}
+
+func TestComparePokemon_EveryFieldHasWinners(t *testing.T) {
+	bulbasaur := &Pokemon{ID: 1, Height: 7, Weight: 69, BaseExp: 64, Stats: []Stat{{BaseStat: 45, Stat: StatInfo{Name: "speed"}}}}
+	charmander := &Pokemon{ID: 4, Height: 6, Weight: 85, BaseExp: 62, Stats: []Stat{{BaseStat: 65, Stat: StatInfo{Name: "speed"}}}}
+
+	comparison := ComparePokemon([]*Pokemon{bulbasaur, charmander})
+
+	assert.Len(t, comparison.Fields, 3+len(StatNames)+1)
+	for _, field := range comparison.Fields {
+		assert.Len(t, field.Deltas, 2, field.Field)
+		assert.NotEmpty(t, field.Winners, field.Field)
+		assert.True(t, field.HigherIsBetter, field.Field)
+	}
+	assert.Equal(t, []int{1}, comparison.Fields[0].Winners)
+	assert.Equal(t, []int{0, -1}, comparison.Fields[0].Deltas)
+	assert.Equal(t, []int{4}, comparison.Fields[1].Winners)
+	assert.Equal(t, []int{1}, comparison.Fields[2].Winners)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+type Encounter struct {
+	Location          string   `json:"location"`
+	Version           string   `json:"version"`
+	Method            string   `json:"method"`
+	Chance            int      `json:"chance"`
+	MinLevel          int      `json:"min_level"`
+	MaxLevel          int      `json:"max_level"`
+	Conditions        []string `json:"conditions,omitempty"`
+	LocalizedLocation string   `json:"localized_location,omitempty"`
+	LocalizedVersion  string   `json:"localized_version,omitempty"`
+}
+
+type EncounterFilter struct {
+	Version string `json:"version,omitempty"`
+}
+
+type PokemonEncounterList struct {
+	PokemonID  int         `json:"pokemon_id"`
+	Count      int         `json:"count"`
+	Encounters []Encounter `json:"encounters"`
+	// Language son los idiomas de las zonas y versiones traducidas.
+	Language string `json:"language,omitempty"`
+	// UpdatedAt es la obtención de los encuentros; solo alimenta
+	// Last-Modified.
+	UpdatedAt time.Time `json:"-"`
+}
+
+func FilterEncounters(encounters []Encounter, filter EncounterFilter) []Encounter {
+	result := []Encounter{}
+	for _, e := range encounters {
+		if filter.Version != "" && e.Version != filter.Version {
+			continue
+		}
+		result = append(result, e)
+	}
+	return result
+}
@@ -9,6 +9,18 @@ var (
 	ErrInternalServer     = errors.New("internal server error")
 	ErrUnauthorized       = errors.New("unauthorized")
 	ErrForbidden          = errors.New("forbidden")
+	ErrTeamNotFound       = errors.New("team not found")
+	ErrInvalidTeamData    = errors.New("invalid team data")
+	ErrInvalidStatParams  = errors.New("invalid stat parameters")
+	ErrMoveNotFound       = errors.New("move not found")
+	ErrAbilityNotFound    = errors.New("ability not found")
+	ErrItemNotFound       = errors.New("item not found")
+	ErrBerryNotFound      = errors.New("berry not found")
+	ErrGenerationNotFound = errors.New("generation not found")
+	ErrPokedexNotFound    = errors.New("pokedex not found")
+	ErrSpriteNotFound     = errors.New("sprite not found")
+	ErrTypeNotFound       = errors.New("type not found")
+	ErrResourceNotFound   = errors.New("resource not found")
 )
 
 type ErrorResponse struct {
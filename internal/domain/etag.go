@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// StrongETag es el ETag fuerte de un contenido: los primeros 128 bits de su
+// SHA-256, entre comillas. Lo usan tanto las respuestas JSON como los sprites.
+func StrongETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return HashETag(sum[:])
+}
+
+// HashETag da formato a un SHA-256 ya calculado, por ejemplo sobre un cuerpo
+// que se ha ido escribiendo por partes.
+func HashETag(sum []byte) string {
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
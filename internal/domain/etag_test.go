@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrongETag(t *testing.T) {
+	hash := sha256.New()
+	hash.Write([]byte(`{"id":25,`))
+	hash.Write([]byte(`"name":"pikachu"}`))
+
+	etag := StrongETag([]byte(`{"id":25,"name":"pikachu"}`))
+
+	assert.Equal(t, HashETag(hash.Sum(nil)), etag)
+	assert.Len(t, etag, 34)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.NotEqual(t, etag, StrongETag([]byte(`{"id":26}`)))
+}
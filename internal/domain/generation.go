@@ -0,0 +1,38 @@
+package domain
+
+type SpeciesRef struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type Generation struct {
+	ID            int          `json:"id"`
+	Name          string       `json:"name"`
+	Region        string       `json:"region"`
+	VersionGroups []string     `json:"version_groups"`
+	Species       []SpeciesRef `json:"species"`
+}
+
+type Pokedex struct {
+	ID      int            `json:"id"`
+	Name    string         `json:"name"`
+	Region  string         `json:"region"`
+	Entries []PokedexEntry `json:"entries"`
+}
+
+type PokedexEntry struct {
+	EntryNumber int        `json:"entry_number"`
+	Species     SpeciesRef `json:"species"`
+}
+
+// SpeciesIDs devuelve los IDs de las especies en el mismo orden; el ID de
+// una especie coincide con el de su variedad por defecto en /pokemon.
+func SpeciesIDs(species []SpeciesRef) []int {
+	ids := make([]int, 0, len(species))
+	for _, s := range species {
+		if id, ok := ResourceID(s.URL); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+type Item struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Cost          int              `json:"cost"`
+	FlingPower    *int             `json:"fling_power"`
+	Category      string           `json:"category"`
+	Attributes    []string         `json:"attributes"`
+	Effect        string           `json:"effect"`
+	ShortEffect   string           `json:"short_effect"`
+	Sprite        string           `json:"sprite"`
+	HeldBy        []ItemHolder     `json:"held_by_pokemon"`
+	Names         LocalizedStrings `json:"names,omitempty"`
+	FlavorTexts   LocalizedStrings `json:"flavor_texts,omitempty"`
+	Language      string           `json:"language,omitempty"`
+	LocalizedName string           `json:"localized_name,omitempty"`
+	FlavorText    string           `json:"flavor_text,omitempty"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+type ItemHolder struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type Berry struct {
+	ID               int           `json:"id"`
+	Name             string        `json:"name"`
+	Item             string        `json:"item"`
+	Firmness         string        `json:"firmness"`
+	GrowthTime       int           `json:"growth_time"`
+	MaxHarvest       int           `json:"max_harvest"`
+	Size             int           `json:"size"`
+	Smoothness       int           `json:"smoothness"`
+	SoilDryness      int           `json:"soil_dryness"`
+	NaturalGiftPower int           `json:"natural_gift_power"`
+	NaturalGiftType  string        `json:"natural_gift_type"`
+	Flavors          []BerryFlavor `json:"flavors"`
+	Language         string        `json:"language,omitempty"`
+	LocalizedName    string        `json:"localized_name,omitempty"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+type BerryFlavor struct {
+	Flavor  string `json:"flavor"`
+	Potency int    `json:"potency"`
+}
+
+type HeldItem struct {
+	Item           ItemInfo          `json:"item"`
+	VersionDetails []HeldItemVersion `json:"version_details"`
+}
+
+type ItemInfo struct {
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	LocalizedName string `json:"localized_name,omitempty"`
+}
+
+type HeldItemVersion struct {
+	Rarity  int    `json:"rarity"`
+	Version string `json:"version"`
+}
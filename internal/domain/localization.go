@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+const DefaultLanguage = "en"
+
+// Languages son los códigos de idioma de PokeAPI, con sus mayúsculas.
+var Languages = []string{
+	"en", "es", "fr", "de", "it", "ja", "ja-Hrkt", "roomaji", "ko",
+	"zh-Hans", "zh-Hant", "cs", "pt-BR",
+}
+
+// NormalizeLanguage traduce una etiqueta BCP 47 cualquiera ("ES", "es-MX",
+// "zh-TW", "pt") al código de PokeAPI que mejor la representa, sin
+// distinguir mayúsculas. Devuelve false si PokeAPI no tiene ese idioma.
+func NormalizeLanguage(tag string) (string, bool) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", false
+	}
+	for _, lang := range Languages {
+		if strings.EqualFold(lang, tag) {
+			return lang, true
+		}
+	}
+
+	base, rest, _ := strings.Cut(tag, "-")
+	if strings.EqualFold(base, "zh") {
+		for _, subtag := range strings.Split(rest, "-") {
+			switch strings.ToUpper(subtag) {
+			case "HANS":
+				return "zh-Hans", true
+			case "TW", "HK", "MO", "HANT":
+				return "zh-Hant", true
+			}
+		}
+		return "zh-Hans", true
+	}
+	for _, lang := range Languages {
+		if strings.EqualFold(lang, base) {
+			return lang, true
+		}
+	}
+	// "pt" o "pt-PT" a la única variante que tiene PokeAPI.
+	for _, lang := range Languages {
+		if langBase, _, _ := strings.Cut(lang, "-"); strings.EqualFold(langBase, base) {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// LocalizedStrings guarda un texto por código de idioma de PokeAPI
+// ("es", "en", "ja-Hrkt", ...).
+type LocalizedStrings map[string]string
+
+// Get devuelve el texto en lang, probando después el idioma base ("es" para
+// "es-MX") y por último inglés.
+func (l LocalizedStrings) Get(lang string) string {
+	text, _ := l.Lookup(lang)
+	return text
+}
+
+// Lookup es Get junto con el idioma del texto devuelto, que es el que debe
+// anunciar Content-Language. Las claves se comparan sin distinguir
+// mayúsculas; si no hay texto ni en inglés, ambos valores son vacíos.
+func (l LocalizedStrings) Lookup(lang string) (string, string) {
+	base, _, _ := strings.Cut(lang, "-")
+	for _, candidate := range []string{lang, base, DefaultLanguage} {
+		for key, text := range l {
+			if text != "" && strings.EqualFold(key, candidate) {
+				return text, key
+			}
+		}
+	}
+	return "", ""
+}
+
+// ServedLanguages reúne los idiomas en que se sirvieron los textos de una
+// respuesta que junta varios recursos, como un learnset o una página de
+// pokémon. String es el valor de Content-Language: el idioma pedido primero y
+// después, ordenados, aquellos a los que cayó algún texto ("es, en"). Es
+// seguro para uso concurrente.
+type ServedLanguages struct {
+	requested string
+	mu        sync.Mutex
+	langs     map[string]bool
+}
+
+func NewServedLanguages(requested string) *ServedLanguages {
+	return &ServedLanguages{requested: requested, langs: map[string]bool{}}
+}
+
+// Add registra el idioma devuelto por Lookup; el vacío (no había texto) se
+// ignora.
+func (s *ServedLanguages) Add(lang string) {
+	if lang == "" {
+		return
+	}
+	s.mu.Lock()
+	s.langs[lang] = true
+	s.mu.Unlock()
+}
+
+func (s *ServedLanguages) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	langs := make([]string, 0, len(s.langs))
+	for lang := range s.langs {
+		if lang != s.requested {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	if s.langs[s.requested] {
+		langs = append([]string{s.requested}, langs...)
+	}
+	return strings.Join(langs, ", ")
+}
+
+// CleanFlavorText quita los saltos de línea y de página que PokeAPI conserva
+// de los textos originales de los juegos.
+func CleanFlavorText(text string) string {
+	replacer := strings.NewReplacer("\n", " ", "\f", " ", "­ ", "", "­", "")
+	return strings.Join(strings.Fields(replacer.Replace(text)), " ")
+}
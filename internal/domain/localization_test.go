@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{tag: "es", want: "es", ok: true},
+		{tag: "ES", want: "es", ok: true},
+		{tag: " es-MX ", want: "es", ok: true},
+		{tag: "ja-hrkt", want: "ja-Hrkt", ok: true},
+		{tag: "zh-TW", want: "zh-Hant", ok: true},
+		{tag: "zh-hant-HK", want: "zh-Hant", ok: true},
+		{tag: "zh", want: "zh-Hans", ok: true},
+		{tag: "zh-CN", want: "zh-Hans", ok: true},
+		{tag: "zh-Hans-HK", want: "zh-Hans", ok: true},
+		{tag: "pt", want: "pt-BR", ok: true},
+		{tag: "pt-PT", want: "pt-BR", ok: true},
+		{tag: "xx"},
+		{tag: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			lang, ok := NormalizeLanguage(tt.tag)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, lang)
+		})
+	}
+}
+
+func TestLocalizedStrings_Lookup(t *testing.T) {
+	names := LocalizedStrings{"en": "Pikachu", "ja-Hrkt": "ピカチュウ", "fr": "", "es": "Pikachu (es)"}
+
+	tests := []struct {
+		name   string
+		lang   string
+		text   string
+		served string
+	}{
+		{name: "Success - exact match", lang: "ja-Hrkt", text: "ピカチュウ", served: "ja-Hrkt"},
+		{name: "Success - case-insensitive", lang: "JA-hrkt", text: "ピカチュウ", served: "ja-Hrkt"},
+		{name: "Success - base language", lang: "es-MX", text: "Pikachu (es)", served: "es"},
+		{name: "Success - empty texts fall back to English", lang: "fr", text: "Pikachu", served: "en"},
+		{name: "Success - missing language falls back to English", lang: "ko", text: "Pikachu", served: "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, served := names.Lookup(tt.lang)
+
+			assert.Equal(t, tt.text, text)
+			assert.Equal(t, tt.served, served)
+			assert.Equal(t, tt.text, names.Get(tt.lang))
+		})
+	}
+
+	t.Run("Error - nothing to serve", func(t *testing.T) {
+		text, served := LocalizedStrings{"fr": "Pikachu"}.Lookup("ko")
+
+		assert.Empty(t, text)
+		assert.Empty(t, served)
+	})
+}
+
+func TestServedLanguages(t *testing.T) {
+	tests := []struct {
+		name  string
+		added []string
+		want  string
+	}{
+		{name: "Success - nothing served", added: []string{"", ""}, want: ""},
+		{name: "Success - requested language", added: []string{"es", "es"}, want: "es"},
+		{name: "Success - fallback only", added: []string{"en", ""}, want: "en"},
+		{name: "Success - requested first", added: []string{"en", "fr", "es", "en"}, want: "es, en, fr"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			served := NewServedLanguages("es")
+			for _, lang := range tt.added {
+				served.Add(lang)
+			}
+
+			assert.Equal(t, tt.want, served.String())
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "time"
+
+type Move struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Type          string           `json:"type"`
+	DamageClass   string           `json:"damage_class"`
+	Power         *int             `json:"power"`
+	Accuracy      *int             `json:"accuracy"`
+	PP            int              `json:"pp"`
+	Priority      int              `json:"priority"`
+	EffectChance  *int             `json:"effect_chance,omitempty"`
+	Effect        string           `json:"effect"`
+	Names         LocalizedStrings `json:"names,omitempty"`
+	FlavorTexts   LocalizedStrings `json:"flavor_texts,omitempty"`
+	Language      string           `json:"language,omitempty"`
+	LocalizedName string           `json:"localized_name,omitempty"`
+	FlavorText    string           `json:"flavor_text,omitempty"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+type MoveInfo struct {
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	LocalizedName string `json:"localized_name,omitempty"`
+}
+
+type PokemonMove struct {
+	Move                MoveInfo          `json:"move"`
+	VersionGroupDetails []MoveLearnDetail `json:"version_group_details"`
+}
+
+type MoveLearnDetail struct {
+	LevelLearnedAt int    `json:"level_learned_at"`
+	LearnMethod    string `json:"learn_method"`
+	VersionGroup   string `json:"version_group"`
+}
+
+type MoveFilter struct {
+	LearnMethod  string `json:"learn_method,omitempty"`
+	VersionGroup string `json:"version_group,omitempty"`
+}
+
+type PokemonMoveList struct {
+	PokemonID int           `json:"pokemon_id"`
+	Count     int           `json:"count"`
+	Moves     []PokemonMove `json:"moves"`
+	// Language son los idiomas en que se sirvieron los nombres de los
+	// movimientos.
+	Language string `json:"language,omitempty"`
+	// UpdatedAt es la obtención del pokémon, que trae el learnset; solo
+	// alimenta Last-Modified.
+	UpdatedAt time.Time `json:"-"`
+}
+
+// FilterMoves conserva solo los detalles que coinciden con el filtro y
+// descarta los movimientos que se quedan sin ninguno.
+func FilterMoves(moves []PokemonMove, filter MoveFilter) []PokemonMove {
+	result := []PokemonMove{}
+	for _, m := range moves {
+		details := []MoveLearnDetail{}
+		for _, d := range m.VersionGroupDetails {
+			if filter.LearnMethod != "" && d.LearnMethod != filter.LearnMethod {
+				continue
+			}
+			if filter.VersionGroup != "" && d.VersionGroup != filter.VersionGroup {
+				continue
+			}
+			details = append(details, d)
+		}
+		if len(details) > 0 {
+			result = append(result, PokemonMove{Move: m.Move, VersionGroupDetails: details})
+		}
+	}
+	return result
+}
+
+// IsDamaging indica si el movimiento hace daño directo (físico o especial).
+func (m *Move) IsDamaging() bool {
+	return m.DamageClass != "status" && m.Power != nil && *m.Power > 0
+}
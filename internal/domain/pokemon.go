@@ -3,19 +3,27 @@ package domain
 import "time"
 
 type Pokemon struct {
-	ID         int       `json:"id"`
-	Name       string    `json:"name" validate:"required"`
-	Height     int       `json:"height"`
-	Weight     int       `json:"weight"`
-	BaseExp    int       `json:"base_experience"`
-	Types      []Type    `json:"types"`
-	Abilities  []Ability `json:"abilities"`
-	Sprites    Sprite    `json:"sprites"`
-	Stats      []Stat    `json:"stats"`
-	IsFavorite bool      `json:"is_favorite"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	PokeAPIID  int       `json:"pokeapi_id"`
+	ID            int        `json:"id"`
+	Name          string     `json:"name" validate:"required"`
+	Height        int        `json:"height"`
+	Weight        int        `json:"weight"`
+	BaseExp       int        `json:"base_experience"`
+	Types         []Type     `json:"types"`
+	Abilities     []Ability  `json:"abilities"`
+	Sprites       Sprite     `json:"sprites"`
+	Stats         []Stat     `json:"stats"`
+	HeldItems     []HeldItem `json:"held_items"`
+	Species       SpeciesRef `json:"species"`
+	Forms         []FormInfo `json:"forms"`
+	Varieties     []Variety  `json:"varieties,omitempty"`
+	Language      string     `json:"language,omitempty"`
+	LocalizedName string     `json:"localized_name,omitempty"`
+	Genus         string     `json:"genus,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	IsFavorite    bool       `json:"is_favorite"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	PokeAPIID     int        `json:"pokeapi_id"`
 }
 
 type PokemonList struct {
@@ -25,6 +33,22 @@ type PokemonList struct {
 	Pokemons *[]Pokemon `json:"pokemons"`
 }
 
+// PokemonSummary es una entrada del listado tal como la da la página de
+// PokeAPI, sin pedir el detalle de cada pokémon. URL enlaza con el detalle en
+// esta API y la rellena la capa HTTP, que es la que conoce sus rutas.
+type PokemonSummary struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type PokemonSummaryList struct {
+	Count    int              `json:"count"`
+	Next     string           `json:"next"`
+	Previous string           `json:"previous"`
+	Pokemons []PokemonSummary `json:"pokemons"`
+}
+
 type Type struct {
 	Slot int      `json:"slot"`
 	Type TypeInfo `json:"type"`
@@ -42,15 +66,22 @@ type Ability struct {
 }
 
 type AbilityInfo struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	LocalizedName string `json:"localized_name,omitempty"`
 }
 
 type Sprite struct {
-	FrontDefault string `json:"front_default"`
-	FrontShiny   string `json:"front_shiny"`
-	BackDefault  string `json:"back_default"`
-	BackShiny    string `json:"back_shiny"`
+	FrontDefault     string                          `json:"front_default"`
+	FrontShiny       string                          `json:"front_shiny"`
+	BackDefault      string                          `json:"back_default"`
+	BackShiny        string                          `json:"back_shiny"`
+	FrontFemale      string                          `json:"front_female,omitempty"`
+	FrontShinyFemale string                          `json:"front_shiny_female,omitempty"`
+	BackFemale       string                          `json:"back_female,omitempty"`
+	BackShinyFemale  string                          `json:"back_shiny_female,omitempty"`
+	Other            map[string]SpriteSet            `json:"other,omitempty"`
+	Versions         map[string]map[string]SpriteSet `json:"versions,omitempty"`
 }
 
 type Stat struct {
@@ -64,9 +95,35 @@ type StatInfo struct {
 	URL  string `json:"url"`
 }
 
+func (p *Pokemon) TypeNames() []string {
+	names := make([]string, 0, len(p.Types))
+	for _, t := range p.Types {
+		names = append(names, t.Type.Name)
+	}
+	return names
+}
+
+func (p *Pokemon) StatTotal() int {
+	total := 0
+	for _, s := range p.Stats {
+		total += s.BaseStat
+	}
+	return total
+}
+
+func (p *Pokemon) BaseStatValue(name string) int {
+	for _, s := range p.Stats {
+		if s.Stat.Name == name {
+			return s.BaseStat
+		}
+	}
+	return 0
+}
+
 type PokemonFilter struct {
 	Name       string `json:"name,omitempty"`
 	Type       string `json:"type,omitempty"`
+	Ability    string `json:"ability,omitempty"`
 	IsFavorite *bool  `json:"is_favorite,omitempty"`
 	Limit      int    `json:"limit,omitempty"`
 	Offset     int    `json:"offset,omitempty"`
@@ -76,4 +133,19 @@ type PokeAPIRepository interface {
 	GetPokemonByID(id int) (*Pokemon, error)
 	GetPokemonByName(name string) (*Pokemon, error)
 	GetPokemonAll(filter PokemonFilter) (*PokemonList, error)
+	GetPokemonSummaries(filter PokemonFilter) (*PokemonSummaryList, error)
+	GetPokemonMoves(id int) ([]PokemonMove, error)
+	GetMoveByName(name string) (*Move, error)
+	GetAbilityByName(name string) (*AbilityDetail, error)
+	GetItemByName(name string) (*Item, error)
+	GetBerryByName(name string) (*Berry, error)
+	// GetPokemonEncounters devuelve también cuándo se obtuvieron de PokeAPI.
+	GetPokemonEncounters(id int) ([]Encounter, time.Time, error)
+	GetGeneration(id string) (*Generation, error)
+	GetPokedex(name string) (*Pokedex, error)
+	GetPokemonSpecies(id int) (*PokemonSpecies, error)
+	GetSpriteImage(url string, size int, format string) (*SpriteImage, error)
+	// GetResourceNames devuelve los nombres traducidos de cualquier recurso
+	// de PokeAPI con lista names ("stat", "version", "location-area"...).
+	GetResourceNames(resource, name string) (LocalizedStrings, error)
 }
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ResourceID extrae el ID numérico del final de una URL de PokeAPI,
+// por ejemplo https://pokeapi.co/api/v2/pokemon/25/ -> 25.
+func ResourceID(url string) (int, bool) {
+	trimmed := strings.TrimRight(url, "/")
+	id, err := strconv.Atoi(trimmed[strings.LastIndex(trimmed, "/")+1:])
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
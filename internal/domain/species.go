@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+type PokemonSpecies struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	Varieties   []Variety        `json:"varieties"`
+	Names       LocalizedStrings `json:"names"`
+	Genera      LocalizedStrings `json:"genera"`
+	FlavorTexts LocalizedStrings `json:"flavor_texts"`
+}
+
+type Variety struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	IsDefault bool   `json:"is_default"`
+}
+
+type FormInfo struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// PokemonVariety es una variedad completa (regional, mega, gigamax...) con
+// sus propios tipos, stats y sprites.
+type PokemonVariety struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	LocalizedName string `json:"localized_name,omitempty"`
+	IsDefault     bool   `json:"is_default"`
+	Types         []Type `json:"types"`
+	Stats         []Stat `json:"stats"`
+	Sprites       Sprite `json:"sprites"`
+}
+
+type PokemonVarietyList struct {
+	SpeciesID        int              `json:"species_id"`
+	Species          string           `json:"species"`
+	LocalizedSpecies string           `json:"localized_species,omitempty"`
+	Count            int              `json:"count"`
+	Varieties        []PokemonVariety `json:"varieties"`
+	// Language son los idiomas del nombre de la especie y de las formas.
+	Language string `json:"language,omitempty"`
+	// UpdatedAt es la obtención más reciente de las variedades; solo
+	// alimenta Last-Modified.
+	UpdatedAt time.Time `json:"-"`
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// SpriteSet reúne todos los campos que PokeAPI usa en los distintos nodos del
+// árbol de sprites; cada nodo solo rellena los que tiene.
+type SpriteSet struct {
+	FrontDefault          string     `json:"front_default,omitempty"`
+	FrontShiny            string     `json:"front_shiny,omitempty"`
+	FrontFemale           string     `json:"front_female,omitempty"`
+	FrontShinyFemale      string     `json:"front_shiny_female,omitempty"`
+	BackDefault           string     `json:"back_default,omitempty"`
+	BackShiny             string     `json:"back_shiny,omitempty"`
+	BackFemale            string     `json:"back_female,omitempty"`
+	BackShinyFemale       string     `json:"back_shiny_female,omitempty"`
+	FrontGray             string     `json:"front_gray,omitempty"`
+	BackGray              string     `json:"back_gray,omitempty"`
+	FrontTransparent      string     `json:"front_transparent,omitempty"`
+	BackTransparent       string     `json:"back_transparent,omitempty"`
+	FrontShinyTransparent string     `json:"front_shiny_transparent,omitempty"`
+	BackShinyTransparent  string     `json:"back_shiny_transparent,omitempty"`
+	Animated              *SpriteSet `json:"animated,omitempty"`
+}
+
+const (
+	SpriteSectionFemale   = "female"
+	SpriteSectionVersions = "versions"
+	SpriteSectionAll      = "all"
+)
+
+// SelectSprites devuelve una copia con los cuatro campos clásicos y solo las
+// secciones pedidas: "female", cualquier clave de other ("official-artwork",
+// "home", "dream-world", "showdown"), "versions", una generación concreta
+// ("generation-iv") o "all".
+func (s Sprite) SelectSprites(sections []string) Sprite {
+	selected := Sprite{
+		FrontDefault: s.FrontDefault,
+		FrontShiny:   s.FrontShiny,
+		BackDefault:  s.BackDefault,
+		BackShiny:    s.BackShiny,
+	}
+
+	for _, section := range sections {
+		section = normalizeSpriteSection(section)
+		switch {
+		case section == SpriteSectionAll:
+			return s
+		case section == SpriteSectionFemale:
+			selected.FrontFemale = s.FrontFemale
+			selected.FrontShinyFemale = s.FrontShinyFemale
+			selected.BackFemale = s.BackFemale
+			selected.BackShinyFemale = s.BackShinyFemale
+		case section == SpriteSectionVersions:
+			// Se copia en un mapa propio: una generación pedida después se
+			// añade a la selección y no al Pokémon compartido de la caché.
+			for generation, versions := range s.Versions {
+				selected.Versions = addGeneration(selected.Versions, generation, versions)
+			}
+		case strings.HasPrefix(section, "generation-"):
+			if generation, ok := s.Versions[section]; ok {
+				selected.Versions = addGeneration(selected.Versions, section, generation)
+			}
+		default:
+			if other, ok := s.Other[section]; ok {
+				if selected.Other == nil {
+					selected.Other = make(map[string]SpriteSet)
+				}
+				selected.Other[section] = other
+			}
+		}
+	}
+	return selected
+}
+
+func addGeneration(versions map[string]map[string]SpriteSet, name string, generation map[string]SpriteSet) map[string]map[string]SpriteSet {
+	if versions == nil {
+		versions = make(map[string]map[string]SpriteSet)
+	}
+	versions[name] = generation
+	return versions
+}
+
+// normalizeSpriteSection acepta tanto "dream-world" como "dream_world",
+// que es la clave que usa PokeAPI.
+func normalizeSpriteSection(section string) string {
+	section = strings.ToLower(strings.TrimSpace(section))
+	if section == "dream-world" {
+		return "dream_world"
+	}
+	return section
+}
+
+const (
+	MinSpriteSize = 16
+	MaxSpriteSize = 512
+)
+
+// Formatos a los que el proxy puede convertir un sprite PNG. Sin formato se
+// sirve el original, o PNG si hay que reescalarlo.
+const (
+	SpriteFormatPNG  = "png"
+	SpriteFormatWebP = "webp"
+)
+
+// SpriteImage son los bytes de un sprite servido por el proxy junto con los
+// validadores HTTP con los que se guardó en disco.
+type SpriteImage struct {
+	Data         []byte
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// SpriteURL resuelve el tipo de sprite pedido en /sprite/:kind. Acepta los
+// campos de primer nivel ("front_default", "back_shiny_female"...) y las
+// claves de other con sufijo opcional "-shiny" ("official-artwork-shiny").
+func (s Sprite) SpriteURL(kind string) (string, bool) {
+	var url string
+	switch kind {
+	case "front_default":
+		url = s.FrontDefault
+	case "front_shiny":
+		url = s.FrontShiny
+	case "back_default":
+		url = s.BackDefault
+	case "back_shiny":
+		url = s.BackShiny
+	case "front_female":
+		url = s.FrontFemale
+	case "front_shiny_female":
+		url = s.FrontShinyFemale
+	case "back_female":
+		url = s.BackFemale
+	case "back_shiny_female":
+		url = s.BackShinyFemale
+	default:
+		section := normalizeSpriteSection(strings.TrimSuffix(kind, "-shiny"))
+		if other, ok := s.Other[section]; ok {
+			url = other.FrontDefault
+			if strings.HasSuffix(kind, "-shiny") {
+				url = other.FrontShiny
+			}
+		}
+	}
+	return url, url != ""
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSprite_SelectSprites(t *testing.T) {
+	newSprite := func() Sprite {
+		return Sprite{
+			FrontDefault: "front.png",
+			FrontShiny:   "front-shiny.png",
+			BackDefault:  "back.png",
+			BackShiny:    "back-shiny.png",
+			FrontFemale:  "front-female.png",
+			Other: map[string]SpriteSet{
+				"official-artwork": {FrontDefault: "artwork.png"},
+				"dream_world":      {FrontDefault: "dream.svg"},
+			},
+			Versions: map[string]map[string]SpriteSet{
+				"generation-i":  {"red-blue": {FrontDefault: "rb.png"}},
+				"generation-iv": {"platinum": {FrontDefault: "pt.png"}},
+			},
+		}
+	}
+	classic := Sprite{
+		FrontDefault: "front.png",
+		FrontShiny:   "front-shiny.png",
+		BackDefault:  "back.png",
+		BackShiny:    "back-shiny.png",
+	}
+
+	tests := []struct {
+		name     string
+		sections []string
+		want     func() Sprite
+	}{
+		{
+			name: "Success - only the classic fields by default",
+			want: func() Sprite { return classic },
+		},
+		{
+			name:     "Success - female fields",
+			sections: []string{"female"},
+			want: func() Sprite {
+				want := classic
+				want.FrontFemale = "front-female.png"
+				return want
+			},
+		},
+		{
+			name:     "Success - other sections accept dashes and any case",
+			sections: []string{" Official-Artwork", "dream-world", "home"},
+			want: func() Sprite {
+				want := classic
+				want.Other = map[string]SpriteSet{
+					"official-artwork": {FrontDefault: "artwork.png"},
+					"dream_world":      {FrontDefault: "dream.svg"},
+				}
+				return want
+			},
+		},
+		{
+			name:     "Success - a single generation",
+			sections: []string{"generation-iv", "generation-ix"},
+			want: func() Sprite {
+				want := classic
+				want.Versions = map[string]map[string]SpriteSet{
+					"generation-iv": {"platinum": {FrontDefault: "pt.png"}},
+				}
+				return want
+			},
+		},
+		{
+			name:     "Success - versions together with a generation",
+			sections: []string{"versions", "generation-i"},
+			want: func() Sprite {
+				want := classic
+				want.Versions = newSprite().Versions
+				return want
+			},
+		},
+		{
+			name:     "Success - all",
+			sections: []string{"all"},
+			want:     newSprite,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sprite := newSprite()
+
+			selected := sprite.SelectSprites(tt.sections)
+
+			assert.Equal(t, tt.want(), selected)
+			assert.Equal(t, newSprite(), sprite)
+		})
+	}
+
+	t.Run("Success - selections never write to the source maps", func(t *testing.T) {
+		sprite := newSprite()
+
+		selected := sprite.SelectSprites([]string{"versions"})
+		selected.Versions["generation-ix"] = nil
+		assert.NotContains(t, sprite.Versions, "generation-ix")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sprite.SelectSprites([]string{"versions", "generation-i", "official-artwork"})
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, newSprite(), sprite)
+	})
+}
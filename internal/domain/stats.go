@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	MaxIV      = 31
+	MaxEV      = 252
+	MaxTotalEV = 510
+)
+
+// StatNames sigue el orden de los stats en PokeAPI y en los arrays de IVs/EVs.
+var StatNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+type Nature struct {
+	Name          string `json:"name"`
+	LocalizedName string `json:"localized_name,omitempty"`
+	Increased     string `json:"increased,omitempty"`
+	Decreased     string `json:"decreased,omitempty"`
+}
+
+var natures = map[string]Nature{
+	"hardy":   {Name: "hardy"},
+	"lonely":  {Name: "lonely", Increased: "attack", Decreased: "defense"},
+	"brave":   {Name: "brave", Increased: "attack", Decreased: "speed"},
+	"adamant": {Name: "adamant", Increased: "attack", Decreased: "special-attack"},
+	"naughty": {Name: "naughty", Increased: "attack", Decreased: "special-defense"},
+	"bold":    {Name: "bold", Increased: "defense", Decreased: "attack"},
+	"docile":  {Name: "docile"},
+	"relaxed": {Name: "relaxed", Increased: "defense", Decreased: "speed"},
+	"impish":  {Name: "impish", Increased: "defense", Decreased: "special-attack"},
+	"lax":     {Name: "lax", Increased: "defense", Decreased: "special-defense"},
+	"timid":   {Name: "timid", Increased: "speed", Decreased: "attack"},
+	"hasty":   {Name: "hasty", Increased: "speed", Decreased: "defense"},
+	"serious": {Name: "serious"},
+	"jolly":   {Name: "jolly", Increased: "speed", Decreased: "special-attack"},
+	"naive":   {Name: "naive", Increased: "speed", Decreased: "special-defense"},
+	"modest":  {Name: "modest", Increased: "special-attack", Decreased: "attack"},
+	"mild":    {Name: "mild", Increased: "special-attack", Decreased: "defense"},
+	"quiet":   {Name: "quiet", Increased: "special-attack", Decreased: "speed"},
+	"bashful": {Name: "bashful"},
+	"rash":    {Name: "rash", Increased: "special-attack", Decreased: "special-defense"},
+	"calm":    {Name: "calm", Increased: "special-defense", Decreased: "attack"},
+	"gentle":  {Name: "gentle", Increased: "special-defense", Decreased: "defense"},
+	"sassy":   {Name: "sassy", Increased: "special-defense", Decreased: "speed"},
+	"careful": {Name: "careful", Increased: "special-defense", Decreased: "special-attack"},
+	"quirky":  {Name: "quirky"},
+}
+
+func GetNature(name string) (Nature, bool) {
+	nature, ok := natures[strings.ToLower(name)]
+	return nature, ok
+}
+
+// Percent devuelve 110, 90 o 100 según cómo afecta la naturaleza al stat.
+// Se trabaja en porcentaje entero para redondear hacia abajo igual que los juegos.
+func (n Nature) Percent(stat string) int {
+	switch stat {
+	case n.Increased:
+		return 110
+	case n.Decreased:
+		return 90
+	}
+	return 100
+}
+
+type StatsRequest struct {
+	Level  int    `json:"level" validate:"min=1,max=100"`
+	Nature string `json:"nature" validate:"required"`
+	IVs    [6]int `json:"ivs" validate:"dive,min=0,max=31"`
+	EVs    [6]int `json:"evs" validate:"dive,min=0,max=252"`
+}
+
+type ComputedStats struct {
+	PokemonID int            `json:"pokemon_id"`
+	Name      string         `json:"name"`
+	Level     int            `json:"level"`
+	Nature    Nature         `json:"nature"`
+	IVs       map[string]int `json:"ivs"`
+	EVs       map[string]int `json:"evs"`
+	Stats     map[string]int `json:"stats"`
+	// StatNames traduce cada stat al idioma pedido.
+	StatNames map[string]string `json:"stat_names,omitempty"`
+	// Language son los idiomas de StatNames y de la naturaleza.
+	Language string `json:"language,omitempty"`
+	// UpdatedAt es la obtención del pokémon; solo alimenta Last-Modified.
+	UpdatedAt time.Time `json:"-"`
+}
+
+// ComputeStats aplica las fórmulas oficiales (Gen III en adelante) a los
+// stats base del Pokémon.
+func ComputeStats(pokemon *Pokemon, req StatsRequest) (*ComputedStats, error) {
+	nature, ok := GetNature(req.Nature)
+	if !ok {
+		return nil, ErrInvalidStatParams
+	}
+
+	totalEV := 0
+	for _, ev := range req.EVs {
+		totalEV += ev
+	}
+	if totalEV > MaxTotalEV {
+		return nil, ErrInvalidStatParams
+	}
+
+	base := make(map[string]int, len(pokemon.Stats))
+	for _, s := range pokemon.Stats {
+		base[s.Stat.Name] = s.BaseStat
+	}
+
+	result := &ComputedStats{
+		PokemonID: pokemon.ID,
+		Name:      pokemon.Name,
+		Level:     req.Level,
+		Nature:    nature,
+		IVs:       make(map[string]int, len(StatNames)),
+		EVs:       make(map[string]int, len(StatNames)),
+		Stats:     make(map[string]int, len(StatNames)),
+	}
+
+	for i, name := range StatNames {
+		iv, ev := req.IVs[i], req.EVs[i]
+		result.IVs[name] = iv
+		result.EVs[name] = ev
+
+		core := (2*base[name] + iv + ev/4) * req.Level / 100
+		if name == "hp" {
+			// Shedinja siempre tiene 1 PS sin importar nivel, IVs ni EVs.
+			if pokemon.Name == "shedinja" {
+				result.Stats[name] = 1
+				continue
+			}
+			result.Stats[name] = core + req.Level + 10
+			continue
+		}
+		result.Stats[name] = (core + 5) * nature.Percent(name) / 100
+	}
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+const MaxTeamSize = 6
+
+type Team struct {
+	ID        string       `json:"id"`
+	UserID    string       `json:"user_id"`
+	Name      string       `json:"name" validate:"required,max=50"`
+	Members   []TeamMember `json:"members" validate:"required,min=1,max=6,dive"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+type TeamMember struct {
+	PokemonID int      `json:"pokemon_id" validate:"required,min=1"`
+	Nickname  string   `json:"nickname,omitempty" validate:"max=12"`
+	Ability   string   `json:"ability,omitempty"`
+	Moves     []string `json:"moves,omitempty" validate:"max=4,dive,required"`
+}
+
+type TeamAnalysis struct {
+	TeamID           string            `json:"team_id"`
+	Members          []TeamMemberStats `json:"members"`
+	Weaknesses       []TypeMatchup     `json:"weaknesses"`
+	SharedWeaknesses []string          `json:"shared_weaknesses"`
+	OffensiveTypes   []string          `json:"offensive_types"`
+	CoverageGaps     []string          `json:"coverage_gaps"`
+	StatDistribution StatDistribution  `json:"stat_distribution"`
+}
+
+type TeamMemberStats struct {
+	PokemonID int            `json:"pokemon_id"`
+	Name      string         `json:"name"`
+	Types     []string       `json:"types"`
+	Stats     map[string]int `json:"stats"`
+	StatTotal int            `json:"stat_total"`
+}
+
+type TypeMatchup struct {
+	Type      string   `json:"type"`
+	Weak      int      `json:"weak"`
+	Resistant int      `json:"resistant"`
+	Immune    int      `json:"immune"`
+	WeakTo    []string `json:"weak_members,omitempty"`
+}
+
+type StatDistribution struct {
+	Totals       map[string]int     `json:"totals"`
+	Averages     map[string]float64 `json:"averages"`
+	MinStatTotal int                `json:"min_stat_total"`
+	MaxStatTotal int                `json:"max_stat_total"`
+	AvgStatTotal float64            `json:"avg_stat_total"`
+}
+
+type TeamRepository interface {
+	Create(team *Team) error
+	GetByID(id string) (*Team, error)
+	ListByUser(userID string) ([]Team, error)
+	Update(team *Team) error
+	Delete(id string) error
+}
@@ -4,4 +4,27 @@ type PokemonUseCase interface {
 	GetPokemonByID(id string) (*Pokemon, error)
 	GetPokemonByName(name string) (*Pokemon, error)
 	GetPokemonAll(filter PokemonFilter) (*PokemonList, error)
+	GetPokemonSummaries(filter PokemonFilter) (*PokemonSummaryList, error)
+	GetPokemonStats(id string, req StatsRequest, lang string) (*ComputedStats, error)
+	ComparePokemon(ids []string, lang string) (*PokemonComparison, error)
+	GetPokemonMoves(id string, filter MoveFilter, lang string) (*PokemonMoveList, error)
+	GetMove(name, lang string) (*Move, error)
+	GetAbility(name, lang string) (*AbilityDetail, error)
+	GetItem(name, lang string) (*Item, error)
+	GetBerry(name, lang string) (*Berry, error)
+	GetPokemonEncounters(id string, filter EncounterFilter, lang string) (*PokemonEncounterList, error)
+	GetPokemonByGeneration(id string, filter PokemonFilter) (*PokemonList, error)
+	GetPokemonByPokedex(name string, filter PokemonFilter) (*PokemonList, error)
+	GetPokemonForms(id, lang string) (*PokemonVarietyList, error)
+	GetPokemonSprite(id, kind string, size int, format string) (*SpriteImage, error)
+	LocalizePokemon(pokemon *Pokemon, lang string) *Pokemon
+}
+
+type TeamUseCase interface {
+	CreateTeam(userID string, team *Team) (*Team, error)
+	GetTeam(userID, id string) (*Team, error)
+	ListTeams(userID string) ([]Team, error)
+	UpdateTeam(userID, id string, team *Team) (*Team, error)
+	DeleteTeam(userID, id string) error
+	AnalyzeTeam(userID, id string) (*TeamAnalysis, error)
 }
@@ -1,25 +1,89 @@
 package infrastructure
 
 import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 )
 
 type CacheItem struct {
-	Value      interface{}
+	Value      []byte
 	ExpiresAt  time.Time
 }
 
-type Cache struct {
+// CacheBackend guarda valores ya serializados durante un TTL; un TTL <= 0
+// significa que la entrada no expira. Las implementaciones deben ser seguras
+// para uso concurrente; los tipos los aporta Cache, que es lo que usa el
+// repositorio.
+type CacheBackend interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(key string)
+	Clear()
+	Size() int
+}
+
+// Cache es un espacio de nombres tipado sobre un CacheBackend: todas sus
+// claves comparten prefijo, TTL y un único tipo de valor. Los valores se
+// guardan serializados en JSON, así que cada lectura devuelve una copia que el
+// llamador puede modificar sin alterar la caché.
+type Cache[K comparable, V any] struct {
+	backend CacheBackend
+	prefix  string
+	ttl     time.Duration
+}
+
+func NewCache[K comparable, V any](backend CacheBackend, prefix string, ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{backend: backend, prefix: prefix, ttl: ttl}
+}
+
+func (c *Cache[K, V]) key(key K) string {
+	return c.prefix + fmt.Sprint(key)
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var value V
+	raw, found := c.backend.Get(c.key(key))
+	if !found {
+		return value, false
+	}
+
+	// Un valor de otro tipo bajo la misma clave cuenta como fallo.
+	if err := json.Unmarshal(raw, &value); err != nil {
+		log.Printf("Failed to decode cache entry %s: %v", c.key(key), err)
+		var zero V
+		return zero, false
+	}
+	return value, true
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Failed to encode cache entry %s: %v", c.key(key), err)
+		return
+	}
+	c.backend.Set(c.key(key), raw, c.ttl)
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.backend.Delete(c.key(key))
+}
+
+// MemoryCache es la caché en memoria del proceso; se pierde al reiniciar.
+type MemoryCache struct {
 	items map[string]CacheItem
 	mu    sync.RWMutex
-	ttl   time.Duration
 }
 
-func NewCache(ttl time.Duration) *Cache {
-	cache := &Cache{
+func NewMemoryCache() *MemoryCache {
+	cache := &MemoryCache{
 		items: make(map[string]CacheItem),
-		ttl:   ttl,
 	}
 	
 	go cache.cleanupExpired()
@@ -27,17 +91,17 @@ func NewCache(ttl time.Duration) *Cache {
 	return cache
 }
 
-func (c *Cache) Set(key string, value interface{}) {
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	
 	c.items[key] = CacheItem{
 		Value:     value,
-		ExpiresAt: time.Now().Add(c.ttl),
+		ExpiresAt: expiresAt(ttl),
 	}
 }
 
-func (c *Cache) Get(key string) (interface{}, bool) {
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
@@ -46,35 +110,35 @@ func (c *Cache) Get(key string) (interface{}, bool) {
 		return nil, false
 	}
 	
-	if time.Now().After(item.ExpiresAt) {
+	if expired(item.ExpiresAt, time.Now()) {
 		return nil, false
 	}
 	
 	return item.Value, true
 }
 
-func (c *Cache) Delete(key string) {
+func (c *MemoryCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	
 	delete(c.items, key)
 }
 
-func (c *Cache) Clear() {
+func (c *MemoryCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	
 	c.items = make(map[string]CacheItem)
 }
 
-func (c *Cache) Size() int {
+func (c *MemoryCache) Size() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	
 	return len(c.items)
 }
 
-func (c *Cache) cleanupExpired() {
+func (c *MemoryCache) cleanupExpired() {
 	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 	
@@ -82,10 +146,105 @@ func (c *Cache) cleanupExpired() {
 		c.mu.Lock()
 		now := time.Now()
 		for key, item := range c.items {
-			if now.After(item.ExpiresAt) {
+			if expired(item.ExpiresAt, now) {
 				delete(c.items, key)
 			}
 		}
 		c.mu.Unlock()
 	}
 }
+
+// newCacheFromEnv elige el backend de caché con CACHE_BACKEND: "memory" (por
+// defecto), "disk", que persiste las entradas en CACHE_DIR, o "redis", que las
+// comparte entre instancias a través de REDIS_ADDR. Con CACHE_L1_SIZE > 0 los
+// backends persistentes se usan como L2 detrás de un LRU local.
+func newCacheFromEnv() (CacheBackend, error) {
+	shared, invalidator, err := openBackend(os.Getenv("CACHE_BACKEND"), os.Getenv("CACHE_DIR"), "cache")
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cache: %w", err)
+	}
+	if _, inMemory := shared.(*MemoryCache); inMemory {
+		return shared, nil
+	}
+
+	l1Size, _ := strconv.Atoi(os.Getenv("CACHE_L1_SIZE"))
+	if l1Size <= 0 {
+		return shared, nil
+	}
+
+	l1TTL := envTTL("CACHE_L1_TTL", 5*time.Minute)
+
+	cache, err := NewTieredCache(NewLRUCache(l1Size, l1TTL), shared, invalidator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tiered cache: %w", err)
+	}
+	log.Printf("L1 cache enabled with %d entries and TTL %v", l1Size, l1TTL)
+	return cache, nil
+}
+
+// openBackend abre un backend "memory", "disk" o "redis". dir es el
+// directorio de "disk"; vacío usa <tmp>/reto-pokemon-api/<name>. Redis se
+// configura siempre con REDIS_ADDR, REDIS_PASSWORD y REDIS_DB y es el único
+// que devuelve un CacheInvalidator.
+func openBackend(kind, dir, name string) (CacheBackend, CacheInvalidator, error) {
+	switch kind {
+	case "", "memory":
+		return NewMemoryCache(), nil, nil
+	case "disk":
+		if dir == "" {
+			dir = filepath.Join(os.TempDir(), "reto-pokemon-api", name)
+		}
+		cache, err := NewDiskCache(dir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open disk %s at %s: %w", name, dir, err)
+		}
+		log.Printf("Disk %s directory: %s", name, dir)
+		return cache, nil, nil
+	case "redis":
+		addr := os.Getenv("REDIS_ADDR")
+		if addr == "" {
+			addr = "localhost:6379"
+		}
+		db, _ := strconv.Atoi(os.Getenv("REDIS_DB"))
+		cache, err := NewRedisCache(addr, os.Getenv("REDIS_PASSWORD"), db)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Printf("Redis %s at %s (db %d)", name, addr, db)
+		return cache, cache, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown backend %q", kind)
+	}
+}
+
+// expiresAt convierte un TTL en fecha de expiración; sin TTL devuelve el
+// tiempo cero, que expired trata como "nunca".
+func expiresAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+func expired(at, now time.Time) bool {
+	return !at.IsZero() && now.After(at)
+}
+
+// envTTL lee un TTL en minutos o, para valores más finos, como duración de Go
+// ("30s", "24h"). Vacío, cero o inválido devuelven fallback.
+func envTTL(name string, fallback time.Duration) time.Duration {
+	raw := os.Getenv(name)
+	if raw == "" || raw == "0" {
+		return fallback
+	}
+
+	if minutes, err := strconv.Atoi(raw); err == nil && minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	if ttl, err := time.ParseDuration(raw); err == nil && ttl > 0 {
+		return ttl
+	}
+
+	log.Printf("Ignoring invalid %s=%q", name, raw)
+	return fallback
+}
@@ -0,0 +1,128 @@
+package infrastructure
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+// cacheNamespaces lista los prefijos usados por newRepositoryCaches. Los
+// backends compartidos los usan para no tocar claves de otros servicios.
+var cacheNamespaces = []string{
+	"pokemon:id:", "pokemon:name:", "pokemon:list:", "pokemon:summary:",
+	"pokemon:moves:", "pokemon:encounters:", "pokemon:species:", "move:name:",
+	"ability:name:", "item:name:", "berry:name:", "type:name:", "generation:",
+	"pokedex:", "names:", "notfound:",
+}
+
+// defaultStaleSize es el número de respuestas de PokeAPI que se conservan
+// para revalidar cuando no se define CACHE_STALE_SIZE.
+const defaultStaleSize = 500
+
+// repositoryCaches agrupa un espacio de nombres tipado por recurso, todos
+// sobre el mismo backend salvo upstream.
+type repositoryCaches struct {
+	pokemonByID      *Cache[int, *domain.Pokemon]
+	pokemonByName    *Cache[string, *domain.Pokemon]
+	pokemonLists     *Cache[listPage, *domain.PokemonList]
+	pokemonSummaries *Cache[listPage, *domain.PokemonSummaryList]
+	pokemonMoves     *Cache[int, []domain.PokemonMove]
+	encounters       *Cache[int, encounterEntry]
+	species          *Cache[int, *domain.PokemonSpecies]
+	moves            *Cache[string, *domain.Move]
+	abilities        *Cache[string, *domain.AbilityDetail]
+	items            *Cache[string, *domain.Item]
+	berries          *Cache[string, *domain.Berry]
+	types            *Cache[string, *domain.TypeDetail]
+	generations      *Cache[string, *domain.Generation]
+	pokedexes        *Cache[string, *domain.Pokedex]
+	names            *Cache[string, domain.LocalizedStrings]
+	notFound         *Cache[string, bool]
+	upstream         *Cache[string, upstreamPokemon]
+}
+
+// cacheTTLs es el TTL de cada recurso. CACHE_TTL es el valor por defecto y
+// CACHE_TTL_<RECURSO> lo sobrescribe; por ejemplo, los datos de un pokémon
+// apenas cambian pero el total del listado crece con cada generación.
+type cacheTTLs struct {
+	pokemon     time.Duration
+	lists       time.Duration
+	species     time.Duration
+	moves       time.Duration
+	abilities   time.Duration
+	items       time.Duration
+	types       time.Duration
+	generations time.Duration
+	notFound    time.Duration
+	stale       time.Duration
+}
+
+func cacheTTLsFromEnv() cacheTTLs {
+	base := envTTL("CACHE_TTL", 60*time.Minute)
+	return cacheTTLs{
+		pokemon:     envTTL("CACHE_TTL_POKEMON", base),
+		lists:       envTTL("CACHE_TTL_LIST", base),
+		species:     envTTL("CACHE_TTL_SPECIES", base),
+		moves:       envTTL("CACHE_TTL_MOVE", base),
+		abilities:   envTTL("CACHE_TTL_ABILITY", base),
+		items:       envTTL("CACHE_TTL_ITEM", base),
+		types:       envTTL("CACHE_TTL_TYPE", base),
+		generations: envTTL("CACHE_TTL_GENERATION", base),
+		notFound:    envTTL("CACHE_NEGATIVE_TTL", time.Minute),
+		stale:       envTTL("CACHE_STALE_TTL", 7*24*time.Hour),
+	}
+}
+
+func newRepositoryCaches(backend CacheBackend, ttls cacheTTLs) *repositoryCaches {
+	return &repositoryCaches{
+		pokemonByID:      NewCache[int, *domain.Pokemon](backend, "pokemon:id:", ttls.pokemon),
+		pokemonByName:    NewCache[string, *domain.Pokemon](backend, "pokemon:name:", ttls.pokemon),
+		pokemonLists:     NewCache[listPage, *domain.PokemonList](backend, "pokemon:list:", ttls.lists),
+		pokemonSummaries: NewCache[listPage, *domain.PokemonSummaryList](backend, "pokemon:summary:", ttls.lists),
+		pokemonMoves:     NewCache[int, []domain.PokemonMove](backend, "pokemon:moves:", ttls.pokemon),
+		encounters:       NewCache[int, encounterEntry](backend, "pokemon:encounters:", ttls.pokemon),
+		species:          NewCache[int, *domain.PokemonSpecies](backend, "pokemon:species:", ttls.species),
+		moves:            NewCache[string, *domain.Move](backend, "move:name:", ttls.moves),
+		abilities:        NewCache[string, *domain.AbilityDetail](backend, "ability:name:", ttls.abilities),
+		items:            NewCache[string, *domain.Item](backend, "item:name:", ttls.items),
+		berries:          NewCache[string, *domain.Berry](backend, "berry:name:", ttls.items),
+		types:            NewCache[string, *domain.TypeDetail](backend, "type:name:", ttls.types),
+		generations:      NewCache[string, *domain.Generation](backend, "generation:", ttls.generations),
+		pokedexes:        NewCache[string, *domain.Pokedex](backend, "pokedex:", ttls.generations),
+		names:            NewCache[string, domain.LocalizedStrings](backend, "names:", ttls.species),
+		notFound:         NewCache[string, bool](backend, "notfound:", ttls.notFound),
+		// Las respuestas para revalidar duplican cada pokémon y su learnset,
+		// así que viven en un LRU propio y acotado en lugar de en el backend
+		// compartido; perderlas solo obliga a descargar de nuevo.
+		upstream: NewCache[string, upstreamPokemon](NewLRUCache(staleSize(), ttls.stale), "upstream:", ttls.stale),
+	}
+}
+
+// staleSize lee CACHE_STALE_SIZE; 0 desactiva la revalidación con PokeAPI.
+func staleSize() int {
+	size, err := strconv.Atoi(os.Getenv("CACHE_STALE_SIZE"))
+	if err != nil || size < 0 {
+		return defaultStaleSize
+	}
+	return size
+}
+
+// listPage identifica una página del listado de pokémon.
+type listPage struct {
+	Offset int
+	Limit  int
+}
+
+func (p listPage) String() string {
+	return fmt.Sprintf("offset:%d:limit:%d", p.Offset, p.Limit)
+}
+
+// CacheMaxAges devuelve el TTL configurado para los recursos y para los
+// listados, para alinear con ellos el Cache-Control de las respuestas.
+func CacheMaxAges() (resource, list time.Duration) {
+	ttls := cacheTTLsFromEnv()
+	return ttls.pokemon, ttls.lists
+}
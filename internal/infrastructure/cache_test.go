@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	backend := NewMemoryCache()
+	pokemon := NewCache[int, *domain.Pokemon](backend, "pokemon:id:", time.Hour)
+
+	t.Run("Success - reads return copies", func(t *testing.T) {
+		original := &domain.Pokemon{ID: 25, Name: "pikachu", Abilities: []domain.Ability{{Slot: 1}}}
+		pokemon.Set(25, original)
+		original.Name = "raichu"
+
+		cached, found := pokemon.Get(25)
+		require.True(t, found)
+		assert.Equal(t, "pikachu", cached.Name)
+
+		cached.Abilities[0].Slot = 2
+		again, _ := pokemon.Get(25)
+		assert.Equal(t, 1, again.Abilities[0].Slot)
+	})
+
+	t.Run("Success - namespaces do not collide", func(t *testing.T) {
+		NewCache[int, []domain.Encounter](backend, "pokemon:encounters:", time.Hour).Set(25, []domain.Encounter{})
+
+		cached, found := pokemon.Get(25)
+		require.True(t, found)
+		assert.Equal(t, 25, cached.ID)
+	})
+
+	t.Run("Success - a TTL of zero never expires", func(t *testing.T) {
+		backend.Set("team:id:abc", []byte(`{}`), 0)
+		backend.Set("pokemon:id:2", []byte(`{}`), time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+
+		_, found := backend.Get("team:id:abc")
+		assert.True(t, found)
+		_, found = backend.Get("pokemon:id:2")
+		assert.False(t, found)
+	})
+
+	t.Run("Error - a value of another type is a miss", func(t *testing.T) {
+		backend.Set("pokemon:id:1", []byte(`[1, 2, 3]`), time.Hour)
+
+		cached, found := pokemon.Get(1)
+		assert.False(t, found)
+		assert.Nil(t, cached)
+	})
+}
+
+func TestCacheTTLsFromEnv(t *testing.T) {
+	t.Setenv("CACHE_TTL", "30")
+	t.Setenv("CACHE_TTL_LIST", "5m")
+	t.Setenv("CACHE_TTL_POKEMON", "24h")
+	t.Setenv("CACHE_TTL_MOVE", "invalid")
+
+	ttls := cacheTTLsFromEnv()
+
+	assert.Equal(t, 24*time.Hour, ttls.pokemon)
+	assert.Equal(t, 5*time.Minute, ttls.lists)
+	assert.Equal(t, 30*time.Minute, ttls.species)
+	assert.Equal(t, 30*time.Minute, ttls.moves)
+	assert.Equal(t, time.Minute, ttls.notFound)
+}
@@ -0,0 +1,127 @@
+package infrastructure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DiskCache guarda cada clave en un archivo JSON con su fecha de expiración
+// absoluta (cero si no expira), de modo que las entradas y sus TTL sobreviven
+// a un reinicio.
+type DiskCache struct {
+	dir string
+}
+
+type diskCacheEntry struct {
+	Key       string          `json:"key"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Value     json.RawMessage `json:"value"`
+}
+
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	cache := &DiskCache{dir: dir}
+	go cache.cleanupExpired()
+
+	return cache, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *DiskCache) Set(key string, value []byte, ttl time.Duration) {
+	raw, err := json.Marshal(diskCacheEntry{
+		Key:       key,
+		ExpiresAt: expiresAt(ttl),
+		Value:     value,
+	})
+	if err != nil {
+		log.Printf("Failed to encode cache entry %s: %v", key, err)
+		return
+	}
+
+	if err := writeFileAtomic(c.path(key), raw); err != nil {
+		log.Printf("Failed to write cache entry %s: %v", key, err)
+	}
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	entry, err := c.readEntry(c.path(key))
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("Failed to read cache entry %s: %v", key, err)
+		}
+		return nil, false
+	}
+
+	// Una colisión de hash o una entrada vencida cuentan como fallo.
+	if entry.Key != key || expired(entry.ExpiresAt, time.Now()) {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (c *DiskCache) Delete(key string) {
+	if err := os.Remove(c.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Failed to delete cache entry %s: %v", key, err)
+	}
+}
+
+func (c *DiskCache) Clear() {
+	for _, path := range c.entries() {
+		os.Remove(path)
+	}
+}
+
+func (c *DiskCache) Size() int {
+	return len(c.entries())
+}
+
+func (c *DiskCache) entries() []string {
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return nil
+	}
+	return paths
+}
+
+func (c *DiskCache) readEntry(path string) (*diskCacheEntry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry diskCacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *DiskCache) cleanupExpired() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		for _, path := range c.entries() {
+			// Las entradas ilegibles tampoco se podrían servir.
+			entry, err := c.readEntry(path)
+			if err != nil || expired(entry.ExpiresAt, now) {
+				os.Remove(path)
+			}
+		}
+	}
+}
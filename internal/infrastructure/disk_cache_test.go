@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := NewDiskCache(dir)
+	require.NoError(t, err)
+
+	t.Run("Success - entries survive a new instance", func(t *testing.T) {
+		NewCache[int, *domain.Pokemon](cache, "pokemon:id:", time.Hour).Set(25, &domain.Pokemon{ID: 25, Name: "pikachu"})
+		NewCache[int, []domain.PokemonMove](cache, "pokemon:moves:", time.Hour).Set(25, []domain.PokemonMove{{Move: domain.MoveInfo{Name: "thunderbolt"}}})
+
+		reopened, err := NewDiskCache(dir)
+		require.NoError(t, err)
+
+		pokemon, found := NewCache[int, *domain.Pokemon](reopened, "pokemon:id:", time.Hour).Get(25)
+		require.True(t, found)
+		assert.Equal(t, "pikachu", pokemon.Name)
+
+		moves, found := NewCache[int, []domain.PokemonMove](reopened, "pokemon:moves:", time.Hour).Get(25)
+		require.True(t, found)
+		assert.Equal(t, "thunderbolt", moves[0].Move.Name)
+		assert.Equal(t, 2, reopened.Size())
+	})
+
+	t.Run("Success - each entry keeps its own TTL", func(t *testing.T) {
+		cache.Set("item:name:potion", []byte(`{"name":"potion"}`), time.Millisecond)
+		cache.Set("team:id:abc", []byte(`{"name":"kanto"}`), 0)
+		time.Sleep(5 * time.Millisecond)
+
+		_, found := cache.Get("item:name:potion")
+		assert.False(t, found)
+		_, found = cache.Get("team:id:abc")
+		assert.True(t, found)
+	})
+
+	t.Run("Success - delete and clear", func(t *testing.T) {
+		cache.Delete("pokemon:id:25")
+		_, found := cache.Get("pokemon:id:25")
+		assert.False(t, found)
+
+		cache.Clear()
+		assert.Equal(t, 0, cache.Size())
+	})
+}
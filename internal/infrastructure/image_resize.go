@@ -0,0 +1,87 @@
+package infrastructure
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/HugoSmits86/nativewebp"
+)
+
+// convertPNG reescala la imagen si size > 0 y la codifica en format: WebP sin
+// pérdidas o, con cualquier otro valor, PNG. Devuelve también el Content-Type.
+func convertPNG(data []byte, size int, format string) ([]byte, string, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if size > 0 {
+		img = resizeImage(img, size)
+	}
+
+	var buf bytes.Buffer
+	if format == domain.SpriteFormatWebP {
+		if err := nativewebp.Encode(&buf, img, nil); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/webp", nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
+
+// resizeImage escala la imagen para que quepa en un cuadrado de size píxeles
+// manteniendo la proporción. Al reducir promedia los píxeles de origen; al
+// ampliar usa el vecino más cercano para no emborronar el pixel art.
+func resizeImage(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := size, size
+	if w > h {
+		dh = max(1, h*size/w)
+	} else if h > w {
+		dw = max(1, w*size/h)
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		sy0 := b.Min.Y + y*h/dh
+		sy1 := max(sy0+1, b.Min.Y+(y+1)*h/dh)
+
+		for x := 0; x < dw; x++ {
+			sx0 := b.Min.X + x*w/dw
+			sx1 := max(sx0+1, b.Min.X+(x+1)*w/dw)
+
+			// RGBA devuelve valores premultiplicados de 16 bits.
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					bl += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			if a == 0 {
+				continue
+			}
+
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r * 0xff / a),
+				G: uint8(g * 0xff / a),
+				B: uint8(bl * 0xff / a),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type PokeAPIAbilityResponse struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	EffectEntries []struct {
+		Effect      string `json:"effect"`
+		ShortEffect string `json:"short_effect"`
+		Language    struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"effect_entries"`
+	Generation struct {
+		Name string `json:"name"`
+	} `json:"generation"`
+	Pokemon []struct {
+		IsHidden bool `json:"is_hidden"`
+		Slot     int  `json:"slot"`
+		Pokemon  struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"pokemon"`
+	Names             []PokeAPIName       `json:"names"`
+	FlavorTextEntries []PokeAPIFlavorText `json:"flavor_text_entries"`
+}
+
+func (r *pokeAPIRepository) GetAbilityByName(name string) (*domain.AbilityDetail, error) {
+	if cached, found := r.caches.abilities.Get(name); found {
+		log.Printf("Cache HIT for ability name: %s", name)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for ability name: %s", name)
+	var apiAbility PokeAPIAbilityResponse
+	url := fmt.Sprintf("%s/ability/%s", r.baseURL, name)
+	if err := r.getJSON(url, &apiAbility, domain.ErrAbilityNotFound); err != nil {
+		return nil, err
+	}
+
+	ability := mapToDomainAbility(&apiAbility)
+	ability.UpdatedAt = time.Now()
+	r.caches.abilities.Set(name, ability)
+	return ability, nil
+}
+
+func mapToDomainAbility(apiAbility *PokeAPIAbilityResponse) *domain.AbilityDetail {
+	ability := &domain.AbilityDetail{
+		ID:          apiAbility.ID,
+		Name:        apiAbility.Name,
+		Generation:  apiAbility.Generation.Name,
+		Pokemon:     make([]domain.AbilityPokemon, len(apiAbility.Pokemon)),
+		Names:       mapLocalizedNames(apiAbility.Names),
+		FlavorTexts: mapFlavorTexts(apiAbility.FlavorTextEntries),
+	}
+
+	for _, e := range apiAbility.EffectEntries {
+		if e.Language.Name == "en" {
+			ability.Effect = e.Effect
+			ability.ShortEffect = e.ShortEffect
+			break
+		}
+	}
+
+	for i, p := range apiAbility.Pokemon {
+		ability.Pokemon[i] = domain.AbilityPokemon{
+			Name:     p.Pokemon.Name,
+			URL:      p.Pokemon.URL,
+			IsHidden: p.IsHidden,
+			Slot:     p.Slot,
+		}
+	}
+	return ability
+}
@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type PokeAPIEncounter struct {
+	LocationArea struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location_area"`
+	VersionDetails []struct {
+		Version struct {
+			Name string `json:"name"`
+		} `json:"version"`
+		EncounterDetails []struct {
+			Chance   int `json:"chance"`
+			MinLevel int `json:"min_level"`
+			MaxLevel int `json:"max_level"`
+			Method   struct {
+				Name string `json:"name"`
+			} `json:"method"`
+			ConditionValues []struct {
+				Name string `json:"name"`
+			} `json:"condition_values"`
+		} `json:"encounter_details"`
+	} `json:"version_details"`
+}
+
+// encounterEntry guarda los encuentros junto con su obtención, que la
+// respuesta usa como Last-Modified.
+type encounterEntry struct {
+	Encounters []domain.Encounter `json:"encounters"`
+	FetchedAt  time.Time          `json:"fetched_at"`
+}
+
+func (r *pokeAPIRepository) GetPokemonEncounters(id int) ([]domain.Encounter, time.Time, error) {
+	if cached, found := r.caches.encounters.Get(id); found {
+		log.Printf("Cache HIT for pokemon encounters ID: %d", id)
+		return cached.Encounters, cached.FetchedAt, nil
+	}
+
+	log.Printf("Cache MISS for pokemon encounters ID: %d", id)
+	var apiEncounters []PokeAPIEncounter
+	url := fmt.Sprintf("%s/pokemon/%d/encounters", r.baseURL, id)
+	if err := r.getJSON(url, &apiEncounters, domain.ErrPokemonNotFound); err != nil {
+		return nil, time.Time{}, err
+	}
+
+	entry := encounterEntry{Encounters: mapToDomainEncounters(apiEncounters), FetchedAt: time.Now()}
+	r.caches.encounters.Set(id, entry)
+	return entry.Encounters, entry.FetchedAt, nil
+}
+
+// mapToDomainEncounters aplana location_area -> version -> detalle en un
+// registro por cada forma de encontrar al Pokémon.
+func mapToDomainEncounters(apiEncounters []PokeAPIEncounter) []domain.Encounter {
+	encounters := []domain.Encounter{}
+	for _, e := range apiEncounters {
+		for _, v := range e.VersionDetails {
+			for _, d := range v.EncounterDetails {
+				conditions := make([]string, len(d.ConditionValues))
+				for i, c := range d.ConditionValues {
+					conditions[i] = c.Name
+				}
+				encounters = append(encounters, domain.Encounter{
+					Location:   e.LocationArea.Name,
+					Version:    v.Version.Name,
+					Method:     d.Method.Name,
+					Chance:     d.Chance,
+					MinLevel:   d.MinLevel,
+					MaxLevel:   d.MaxLevel,
+					Conditions: conditions,
+				})
+			}
+		}
+	}
+	return encounters
+}
@@ -0,0 +1,96 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type PokeAPIGenerationResponse struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	MainRegion struct {
+		Name string `json:"name"`
+	} `json:"main_region"`
+	VersionGroups []struct {
+		Name string `json:"name"`
+	} `json:"version_groups"`
+	PokemonSpecies []PokeAPIResult `json:"pokemon_species"`
+}
+
+type PokeAPIPokedexResponse struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Region *struct {
+		Name string `json:"name"`
+	} `json:"region"`
+	PokemonEntries []struct {
+		EntryNumber    int           `json:"entry_number"`
+		PokemonSpecies PokeAPIResult `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+func (r *pokeAPIRepository) GetGeneration(id string) (*domain.Generation, error) {
+	if cached, found := r.caches.generations.Get(id); found {
+		log.Printf("Cache HIT for generation: %s", id)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for generation: %s", id)
+	var apiGeneration PokeAPIGenerationResponse
+	url := fmt.Sprintf("%s/generation/%s", r.baseURL, id)
+	if err := r.getJSON(url, &apiGeneration, domain.ErrGenerationNotFound); err != nil {
+		return nil, err
+	}
+
+	generation := &domain.Generation{
+		ID:            apiGeneration.ID,
+		Name:          apiGeneration.Name,
+		Region:        apiGeneration.MainRegion.Name,
+		VersionGroups: make([]string, len(apiGeneration.VersionGroups)),
+		Species:       make([]domain.SpeciesRef, len(apiGeneration.PokemonSpecies)),
+	}
+	for i, v := range apiGeneration.VersionGroups {
+		generation.VersionGroups[i] = v.Name
+	}
+	for i, s := range apiGeneration.PokemonSpecies {
+		generation.Species[i] = domain.SpeciesRef{Name: s.Name, URL: s.URL}
+	}
+
+	r.caches.generations.Set(id, generation)
+	return generation, nil
+}
+
+func (r *pokeAPIRepository) GetPokedex(name string) (*domain.Pokedex, error) {
+	if cached, found := r.caches.pokedexes.Get(name); found {
+		log.Printf("Cache HIT for pokedex: %s", name)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for pokedex: %s", name)
+	var apiPokedex PokeAPIPokedexResponse
+	url := fmt.Sprintf("%s/pokedex/%s", r.baseURL, name)
+	if err := r.getJSON(url, &apiPokedex, domain.ErrPokedexNotFound); err != nil {
+		return nil, err
+	}
+
+	pokedex := &domain.Pokedex{
+		ID:      apiPokedex.ID,
+		Name:    apiPokedex.Name,
+		Entries: make([]domain.PokedexEntry, len(apiPokedex.PokemonEntries)),
+	}
+	// La pokédex nacional no pertenece a ninguna región.
+	if apiPokedex.Region != nil {
+		pokedex.Region = apiPokedex.Region.Name
+	}
+	for i, e := range apiPokedex.PokemonEntries {
+		pokedex.Entries[i] = domain.PokedexEntry{
+			EntryNumber: e.EntryNumber,
+			Species:     domain.SpeciesRef{Name: e.PokemonSpecies.Name, URL: e.PokemonSpecies.URL},
+		}
+	}
+
+	r.caches.pokedexes.Set(name, pokedex)
+	return pokedex, nil
+}
@@ -0,0 +1,206 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type PokeAPIHeldItem struct {
+	Item struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"item"`
+	VersionDetails []struct {
+		Rarity  int `json:"rarity"`
+		Version struct {
+			Name string `json:"name"`
+		} `json:"version"`
+	} `json:"version_details"`
+}
+
+type PokeAPIItemResponse struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Cost       int    `json:"cost"`
+	FlingPower *int   `json:"fling_power"`
+	Category   struct {
+		Name string `json:"name"`
+	} `json:"category"`
+	Attributes []struct {
+		Name string `json:"name"`
+	} `json:"attributes"`
+	EffectEntries []struct {
+		Effect      string `json:"effect"`
+		ShortEffect string `json:"short_effect"`
+		Language    struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"effect_entries"`
+	Sprites struct {
+		Default string `json:"default"`
+	} `json:"sprites"`
+	HeldByPokemon []struct {
+		Pokemon struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon"`
+	} `json:"held_by_pokemon"`
+	Names []PokeAPIName `json:"names"`
+	// Los objetos usan "text" en vez del "flavor_text" de movimientos y
+	// habilidades.
+	FlavorTextEntries []struct {
+		Text     string          `json:"text"`
+		Language PokeAPILanguage `json:"language"`
+	} `json:"flavor_text_entries"`
+}
+
+type PokeAPIBerryResponse struct {
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	GrowthTime       int    `json:"growth_time"`
+	MaxHarvest       int    `json:"max_harvest"`
+	NaturalGiftPower int    `json:"natural_gift_power"`
+	Size             int    `json:"size"`
+	Smoothness       int    `json:"smoothness"`
+	SoilDryness      int    `json:"soil_dryness"`
+	Firmness         struct {
+		Name string `json:"name"`
+	} `json:"firmness"`
+	NaturalGiftType struct {
+		Name string `json:"name"`
+	} `json:"natural_gift_type"`
+	Flavors []struct {
+		Potency int `json:"potency"`
+		Flavor  struct {
+			Name string `json:"name"`
+		} `json:"flavor"`
+	} `json:"flavors"`
+	Item struct {
+		Name string `json:"name"`
+	} `json:"item"`
+}
+
+func (r *pokeAPIRepository) GetItemByName(name string) (*domain.Item, error) {
+	if cached, found := r.caches.items.Get(name); found {
+		log.Printf("Cache HIT for item name: %s", name)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for item name: %s", name)
+	var apiItem PokeAPIItemResponse
+	url := fmt.Sprintf("%s/item/%s", r.baseURL, name)
+	if err := r.getJSON(url, &apiItem, domain.ErrItemNotFound); err != nil {
+		return nil, err
+	}
+
+	item := mapToDomainItem(&apiItem)
+	item.UpdatedAt = time.Now()
+	r.caches.items.Set(name, item)
+	return item, nil
+}
+
+func (r *pokeAPIRepository) GetBerryByName(name string) (*domain.Berry, error) {
+	if cached, found := r.caches.berries.Get(name); found {
+		log.Printf("Cache HIT for berry name: %s", name)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for berry name: %s", name)
+	var apiBerry PokeAPIBerryResponse
+	url := fmt.Sprintf("%s/berry/%s", r.baseURL, name)
+	if err := r.getJSON(url, &apiBerry, domain.ErrBerryNotFound); err != nil {
+		return nil, err
+	}
+
+	berry := mapToDomainBerry(&apiBerry)
+	berry.UpdatedAt = time.Now()
+	r.caches.berries.Set(name, berry)
+	return berry, nil
+}
+
+func mapToDomainHeldItems(apiItems []PokeAPIHeldItem) []domain.HeldItem {
+	items := make([]domain.HeldItem, len(apiItems))
+	for i, h := range apiItems {
+		versions := make([]domain.HeldItemVersion, len(h.VersionDetails))
+		for j, v := range h.VersionDetails {
+			versions[j] = domain.HeldItemVersion{
+				Rarity:  v.Rarity,
+				Version: v.Version.Name,
+			}
+		}
+		items[i] = domain.HeldItem{
+			Item: domain.ItemInfo{
+				Name: h.Item.Name,
+				URL:  h.Item.URL,
+			},
+			VersionDetails: versions,
+		}
+	}
+	return items
+}
+
+func mapToDomainItem(apiItem *PokeAPIItemResponse) *domain.Item {
+	item := &domain.Item{
+		ID:         apiItem.ID,
+		Name:       apiItem.Name,
+		Cost:       apiItem.Cost,
+		FlingPower: apiItem.FlingPower,
+		Category:   apiItem.Category.Name,
+		Sprite:     apiItem.Sprites.Default,
+		Attributes: make([]string, len(apiItem.Attributes)),
+		HeldBy:     make([]domain.ItemHolder, len(apiItem.HeldByPokemon)),
+	}
+
+	for i, a := range apiItem.Attributes {
+		item.Attributes[i] = a.Name
+	}
+	for _, e := range apiItem.EffectEntries {
+		if e.Language.Name == "en" {
+			item.Effect = e.Effect
+			item.ShortEffect = e.ShortEffect
+			break
+		}
+	}
+	for i, h := range apiItem.HeldByPokemon {
+		item.HeldBy[i] = domain.ItemHolder{
+			Name: h.Pokemon.Name,
+			URL:  h.Pokemon.URL,
+		}
+	}
+
+	item.Names = mapLocalizedNames(apiItem.Names)
+	flavorTexts := make([]PokeAPIFlavorText, len(apiItem.FlavorTextEntries))
+	for i, e := range apiItem.FlavorTextEntries {
+		flavorTexts[i] = PokeAPIFlavorText{FlavorText: e.Text, Language: e.Language}
+	}
+	item.FlavorTexts = mapFlavorTexts(flavorTexts)
+	return item
+}
+
+func mapToDomainBerry(apiBerry *PokeAPIBerryResponse) *domain.Berry {
+	berry := &domain.Berry{
+		ID:               apiBerry.ID,
+		Name:             apiBerry.Name,
+		Item:             apiBerry.Item.Name,
+		Firmness:         apiBerry.Firmness.Name,
+		GrowthTime:       apiBerry.GrowthTime,
+		MaxHarvest:       apiBerry.MaxHarvest,
+		Size:             apiBerry.Size,
+		Smoothness:       apiBerry.Smoothness,
+		SoilDryness:      apiBerry.SoilDryness,
+		NaturalGiftPower: apiBerry.NaturalGiftPower,
+		NaturalGiftType:  apiBerry.NaturalGiftType.Name,
+		Flavors:          make([]domain.BerryFlavor, len(apiBerry.Flavors)),
+	}
+
+	for i, f := range apiBerry.Flavors {
+		berry.Flavors[i] = domain.BerryFlavor{
+			Flavor:  f.Flavor.Name,
+			Potency: f.Potency,
+		}
+	}
+	return berry
+}
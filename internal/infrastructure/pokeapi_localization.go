@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type PokeAPILanguage struct {
+	Name string `json:"name"`
+}
+
+type PokeAPIName struct {
+	Name     string          `json:"name"`
+	Language PokeAPILanguage `json:"language"`
+}
+
+type PokeAPIGenus struct {
+	Genus    string          `json:"genus"`
+	Language PokeAPILanguage `json:"language"`
+}
+
+type PokeAPIFlavorText struct {
+	FlavorText string          `json:"flavor_text"`
+	Language   PokeAPILanguage `json:"language"`
+}
+
+func mapLocalizedNames(names []PokeAPIName) domain.LocalizedStrings {
+	result := make(domain.LocalizedStrings, len(names))
+	for _, n := range names {
+		result[n.Language.Name] = n.Name
+	}
+	return result
+}
+
+func mapLocalizedGenera(genera []PokeAPIGenus) domain.LocalizedStrings {
+	result := make(domain.LocalizedStrings, len(genera))
+	for _, g := range genera {
+		result[g.Language.Name] = g.Genus
+	}
+	return result
+}
+
+// mapFlavorTexts se queda con la última entrada de cada idioma; PokeAPI las
+// ordena de la versión más antigua a la más reciente.
+func mapFlavorTexts(entries []PokeAPIFlavorText) domain.LocalizedStrings {
+	result := make(domain.LocalizedStrings)
+	for _, e := range entries {
+		result[e.Language.Name] = domain.CleanFlavorText(e.FlavorText)
+	}
+	return result
+}
+
+// GetResourceNames descarga /<resource>/<name> y se queda solo con su lista
+// names. Se cachea aparte del recurso, que en la mayoría de casos no se usa
+// para nada más.
+func (r *pokeAPIRepository) GetResourceNames(resource, name string) (domain.LocalizedStrings, error) {
+	key := resource + "/" + name
+	if cached, found := r.caches.names.Get(key); found {
+		log.Printf("Cache HIT for names: %s", key)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for names: %s", key)
+	var apiResource struct {
+		Names []PokeAPIName `json:"names"`
+	}
+	url := fmt.Sprintf("%s/%s/%s", r.baseURL, resource, name)
+	if err := r.getJSON(url, &apiResource, domain.ErrResourceNotFound); err != nil {
+		return nil, err
+	}
+
+	names := mapLocalizedNames(apiResource.Names)
+	r.caches.names.Set(key, names)
+	return names, nil
+}
@@ -0,0 +1,136 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type PokeAPIMove struct {
+	Move struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"move"`
+	VersionGroupDetails []struct {
+		LevelLearnedAt  int `json:"level_learned_at"`
+		MoveLearnMethod struct {
+			Name string `json:"name"`
+		} `json:"move_learn_method"`
+		VersionGroup struct {
+			Name string `json:"name"`
+		} `json:"version_group"`
+	} `json:"version_group_details"`
+}
+
+type PokeAPIMoveResponse struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Power        *int   `json:"power"`
+	Accuracy     *int   `json:"accuracy"`
+	PP           int    `json:"pp"`
+	Priority     int    `json:"priority"`
+	EffectChance *int   `json:"effect_chance"`
+	DamageClass  struct {
+		Name string `json:"name"`
+	} `json:"damage_class"`
+	Type struct {
+		Name string `json:"name"`
+	} `json:"type"`
+	EffectEntries []struct {
+		ShortEffect string `json:"short_effect"`
+		Language    struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"effect_entries"`
+	Names             []PokeAPIName       `json:"names"`
+	FlavorTextEntries []PokeAPIFlavorText `json:"flavor_text_entries"`
+}
+
+func (r *pokeAPIRepository) GetPokemonMoves(id int) ([]domain.PokemonMove, error) {
+	if cached, found := r.caches.pokemonMoves.Get(id); found {
+		log.Printf("Cache HIT for pokemon moves ID: %d", id)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for pokemon moves ID: %d", id)
+	url := fmt.Sprintf("%s/pokemon/%d", r.baseURL, id)
+	pokemon, moves, err := r.fetchPokemon(url)
+	if err != nil {
+		return nil, err
+	}
+	r.caches.pokemonByID.Set(id, pokemon)
+	return moves, nil
+}
+
+func (r *pokeAPIRepository) GetMoveByName(name string) (*domain.Move, error) {
+	if cached, found := r.caches.moves.Get(name); found {
+		log.Printf("Cache HIT for move name: %s", name)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for move name: %s", name)
+	var apiMove PokeAPIMoveResponse
+	url := fmt.Sprintf("%s/move/%s", r.baseURL, name)
+	if err := r.getJSON(url, &apiMove, domain.ErrMoveNotFound); err != nil {
+		return nil, err
+	}
+
+	move := mapToDomainMove(&apiMove)
+	move.UpdatedAt = time.Now()
+	r.caches.moves.Set(name, move)
+	return move, nil
+}
+
+func mapToDomainMoves(apiMoves []PokeAPIMove) []domain.PokemonMove {
+	moves := make([]domain.PokemonMove, len(apiMoves))
+	for i, m := range apiMoves {
+		details := make([]domain.MoveLearnDetail, len(m.VersionGroupDetails))
+		for j, d := range m.VersionGroupDetails {
+			details[j] = domain.MoveLearnDetail{
+				LevelLearnedAt: d.LevelLearnedAt,
+				LearnMethod:    d.MoveLearnMethod.Name,
+				VersionGroup:   d.VersionGroup.Name,
+			}
+		}
+		moves[i] = domain.PokemonMove{
+			Move: domain.MoveInfo{
+				Name: m.Move.Name,
+				URL:  m.Move.URL,
+			},
+			VersionGroupDetails: details,
+		}
+	}
+	return moves
+}
+
+func mapToDomainMove(apiMove *PokeAPIMoveResponse) *domain.Move {
+	effect := ""
+	for _, e := range apiMove.EffectEntries {
+		if e.Language.Name == "en" {
+			effect = e.ShortEffect
+			break
+		}
+	}
+	if apiMove.EffectChance != nil {
+		effect = strings.ReplaceAll(effect, "$effect_chance", strconv.Itoa(*apiMove.EffectChance))
+	}
+
+	return &domain.Move{
+		ID:           apiMove.ID,
+		Name:         apiMove.Name,
+		Type:         apiMove.Type.Name,
+		DamageClass:  apiMove.DamageClass.Name,
+		Power:        apiMove.Power,
+		Accuracy:     apiMove.Accuracy,
+		PP:           apiMove.PP,
+		Priority:     apiMove.Priority,
+		EffectChance: apiMove.EffectChance,
+		Effect:       effect,
+		Names:        mapLocalizedNames(apiMove.Names),
+		FlavorTexts:  mapFlavorTexts(apiMove.FlavorTextEntries),
+	}
+}
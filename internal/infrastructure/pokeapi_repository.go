@@ -6,7 +6,7 @@ import (
 	"log"
 	"net/http"
 	"os"
-	"strconv"
+	"path/filepath"
 	"time"
 
 	"reto-pokemon-api/internal/domain"
@@ -15,49 +15,71 @@ import (
 type pokeAPIRepository struct {
 	client  *http.Client
 	baseURL string
-	cache   *Cache
+	caches  *repositoryCaches
+	sprites *spriteStore
 }
 
-func NewPokeAPIRepository() domain.PokeAPIRepository {
+func NewPokeAPIRepository() (domain.PokeAPIRepository, error) {
+	return newPokeAPIRepository(pokeAPIBaseURL(), http.DefaultTransport)
+}
+
+func pokeAPIBaseURL() string {
 	baseURL := os.Getenv("POKEAPI_BASE_URL")
 	if baseURL == "" {
 		baseURL = "https://pokeapi.co/api/v2"
 	}
+	return baseURL
+}
+
+// newPokeAPIRepository comparte la configuración de caché entre la API real
+// y el modo offline, que solo cambia el transporte HTTP.
+func newPokeAPIRepository(baseURL string, transport http.RoundTripper) (*pokeAPIRepository, error) {
+	return newPokeAPIRepositoryWithTTLs(baseURL, transport, cacheTTLsFromEnv())
+}
 
-	cachettlEnv := os.Getenv("CACHE_TTL")
-	if cachettlEnv == "0" {
-		cachettlEnv = "60"
+func newPokeAPIRepositoryWithTTLs(baseURL string, transport http.RoundTripper, ttls cacheTTLs) (*pokeAPIRepository, error) {
+	log.Printf("Initializing cache with TTL: %v (lists: %v, not found: %v)", ttls.pokemon, ttls.lists, ttls.notFound)
+
+	spriteDir := os.Getenv("SPRITE_CACHE_DIR")
+	if spriteDir == "" {
+		spriteDir = filepath.Join(os.TempDir(), "reto-pokemon-api", "sprites")
 	}
-	
-	cacheTTL := 60 * time.Minute
-	if ttlEnv := cachettlEnv; ttlEnv != "" {
-		if ttlMinutes, err := strconv.Atoi(ttlEnv); err == nil {
-			cacheTTL = time.Duration(ttlMinutes) * time.Minute
-			log.Printf("Cache TTL configured to %d minutes", ttlMinutes)
-		}
+	sprites, err := newSpriteStore(spriteDir)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Sprite cache directory: %s", spriteDir)
+
+	cache, err := newCacheFromEnv()
+	if err != nil {
+		return nil, err
 	}
-	
-	log.Printf("Initializing cache with TTL: %v", cacheTTL)
 	
 	return &pokeAPIRepository{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: transport,
 		},
 		baseURL: baseURL,
-		cache:   NewCache(cacheTTL),
-	}
+		caches:  newRepositoryCaches(cache, ttls),
+		sprites: sprites,
+	}, nil
 }
 
 type PokeAPIResponse struct {
-	ID             int              `json:"id"`
-	Name           string           `json:"name"`
-	Height         int              `json:"height"`
-	Weight         int              `json:"weight"`
-	BaseExperience int              `json:"base_experience"`
-	Types          []PokeAPIType    `json:"types"`
-	Abilities      []PokeAPIAbility `json:"abilities"`
-	Sprites        PokeAPISprites   `json:"sprites"`
-	Stats          []PokeAPIStat    `json:"stats"`
+	ID             int               `json:"id"`
+	Name           string            `json:"name"`
+	Height         int               `json:"height"`
+	Weight         int               `json:"weight"`
+	BaseExperience int               `json:"base_experience"`
+	Types          []PokeAPIType     `json:"types"`
+	Abilities      []PokeAPIAbility  `json:"abilities"`
+	Sprites        PokeAPISprites    `json:"sprites"`
+	Stats          []PokeAPIStat     `json:"stats"`
+	Moves          []PokeAPIMove     `json:"moves"`
+	HeldItems      []PokeAPIHeldItem `json:"held_items"`
+	Species        PokeAPIResult     `json:"species"`
+	Forms          []PokeAPIResult   `json:"forms"`
 }
 
 type PokeAPIResult struct {
@@ -89,10 +111,16 @@ type PokeAPIAbility struct {
 }
 
 type PokeAPISprites struct {
-	FrontDefault string `json:"front_default"`
-	FrontShiny   string `json:"front_shiny"`
-	BackDefault  string `json:"back_default"`
-	BackShiny    string `json:"back_shiny"`
+	FrontDefault     string                                 `json:"front_default"`
+	FrontShiny       string                                 `json:"front_shiny"`
+	BackDefault      string                                 `json:"back_default"`
+	BackShiny        string                                 `json:"back_shiny"`
+	FrontFemale      string                                 `json:"front_female"`
+	FrontShinyFemale string                                 `json:"front_shiny_female"`
+	BackFemale       string                                 `json:"back_female"`
+	BackShinyFemale  string                                 `json:"back_shiny_female"`
+	Other            map[string]domain.SpriteSet            `json:"other"`
+	Versions         map[string]map[string]domain.SpriteSet `json:"versions"`
 }
 
 type PokeAPIStat struct {
@@ -106,17 +134,16 @@ type PokeAPIStat struct {
 
 func (r *pokeAPIRepository) GetPokemonByID(id int) (*domain.Pokemon, error) {
 
-	cacheKey := fmt.Sprintf("pokemon:id:%d", id)
-	if cached, found := r.cache.Get(cacheKey); found {
+	if cached, found := r.caches.pokemonByID.Get(id); found {
 		log.Printf("Cache HIT for pokemon ID: %d", id)
-		return cached.(*domain.Pokemon), nil
+		return cached, nil
 	}
 	log.Printf("Cache MISS for pokemon ID: %d", id)
 	url := fmt.Sprintf("%s/pokemon/%d", r.baseURL, id)
-	pokemon, err := r.fetchPokemon(url)
+	pokemon, _, err := r.fetchPokemon(url)
 	
 	if err == nil && pokemon != nil {
-		r.cache.Set(cacheKey, pokemon)
+		r.caches.pokemonByID.Set(id, pokemon)
 	}
 	
 	return pokemon, err
@@ -124,74 +151,168 @@ func (r *pokeAPIRepository) GetPokemonByID(id int) (*domain.Pokemon, error) {
 
 func (r *pokeAPIRepository) GetPokemonByName(name string) (*domain.Pokemon, error) {
 
-	cacheKey := fmt.Sprintf("pokemon:name:%s", name)
-	if cached, found := r.cache.Get(cacheKey); found {
+	if cached, found := r.caches.pokemonByName.Get(name); found {
 		log.Printf("Cache HIT for pokemon name: %s", name)
-		return cached.(*domain.Pokemon), nil
+		return cached, nil
 	}
 	
 	log.Printf("Cache MISS for pokemon name: %s", name)
 	url := fmt.Sprintf("%s/pokemon/%s", r.baseURL, name)
-	pokemon, err := r.fetchPokemon(url)
+	pokemon, _, err := r.fetchPokemon(url)
 	
 	if err == nil && pokemon != nil {
-		r.cache.Set(cacheKey, pokemon)
+		r.caches.pokemonByName.Set(name, pokemon)
 	}
 	
 	return pokemon, err
 }
 
 func (r *pokeAPIRepository) GetPokemonAll(filter domain.PokemonFilter) (*domain.PokemonList, error) {
-	offset := 0
-	limitset := 20
-	
-	if filter.Offset > 0 {
-		offset = filter.Offset
-	}
-	if filter.Limit > 0 {
-		limitset = filter.Limit
-	}
-	
-	cacheKey := fmt.Sprintf("pokemon:list:offset:%d:limit:%d", offset, limitset)
-	if cached, found := r.cache.Get(cacheKey); found {
-		log.Printf("Cache HIT for pokemon list (offset: %d, limit: %d)", offset, limitset)
-		return cached.(*domain.PokemonList), nil
+	page := pageFromFilter(filter)
+	if cached, found := r.caches.pokemonLists.Get(page); found {
+		log.Printf("Cache HIT for pokemon list (offset: %d, limit: %d)", page.Offset, page.Limit)
+		return cached, nil
 	}
 	
-	log.Printf("Cache MISS for pokemon list (offset: %d, limit: %d)", offset, limitset)
-	url := fmt.Sprintf("%s/pokemon?offset=%d&limit=%d", r.baseURL, offset, limitset)
+	log.Printf("Cache MISS for pokemon list (offset: %d, limit: %d)", page.Offset, page.Limit)
+	url := fmt.Sprintf("%s/pokemon?offset=%d&limit=%d", r.baseURL, page.Offset, page.Limit)
 	log.Println("url:", url)
 	
 	pokemonList, err := r.fetchPokemonAll(url)
 	
 	if err == nil && pokemonList != nil {
-		r.cache.Set(cacheKey, pokemonList)
+		r.caches.pokemonLists.Set(page, pokemonList)
 	}
 	
 	return pokemonList, err
 }
 
-func (r *pokeAPIRepository) fetchPokemon(url string) (*domain.Pokemon, error) {
-	resp, err := r.client.Get(url)
+// GetPokemonSummaries devuelve la página del listado con lo que trae PokeAPI
+// (nombre y URL) en una sola petición, sin el fan-out de fetchPokemonAll.
+func (r *pokeAPIRepository) GetPokemonSummaries(filter domain.PokemonFilter) (*domain.PokemonSummaryList, error) {
+	page := pageFromFilter(filter)
+	if cached, found := r.caches.pokemonSummaries.Get(page); found {
+		log.Printf("Cache HIT for pokemon summaries (offset: %d, limit: %d)", page.Offset, page.Limit)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for pokemon summaries (offset: %d, limit: %d)", page.Offset, page.Limit)
+	url := fmt.Sprintf("%s/pokemon?offset=%d&limit=%d", r.baseURL, page.Offset, page.Limit)
+
+	var apiList PokeAPIResponseList
+	if err := r.getJSON(url, &apiList, domain.ErrPokemonNotFound); err != nil {
+		return nil, err
+	}
+
+	summaries := make([]domain.PokemonSummary, 0, len(apiList.Results))
+	for _, result := range apiList.Results {
+		id, _ := domain.ResourceID(result.URL)
+		summaries = append(summaries, domain.PokemonSummary{ID: id, Name: result.Name})
+	}
+
+	list := &domain.PokemonSummaryList{
+		Count:    apiList.Count,
+		Next:     apiList.Next,
+		Previous: apiList.Previous,
+		Pokemons: summaries,
+	}
+	r.caches.pokemonSummaries.Set(page, list)
+	return list, nil
+}
+
+// pageFromFilter aplica los valores por defecto de PokeAPI (offset 0, 20 por página).
+func pageFromFilter(filter domain.PokemonFilter) listPage {
+	page := listPage{Offset: 0, Limit: 20}
+	if filter.Offset > 0 {
+		page.Offset = filter.Offset
+	}
+	if filter.Limit > 0 {
+		page.Limit = filter.Limit
+	}
+	return page
+}
+
+// fetchPokemon revalida con PokeAPI si ya se descargó antes: un 304 reutiliza
+// el pokémon ya mapeado y solo un 200 se vuelve a decodificar. Devuelve
+// también el learnset, que llega en la misma respuesta.
+func (r *pokeAPIRepository) fetchPokemon(url string) (*domain.Pokemon, []domain.PokemonMove, error) {
+	previous, _ := r.caches.upstream.Get(url)
+
+	var pokeAPIResp PokeAPIResponse
+	validators, notModified, err := r.fetchJSON(url, &pokeAPIResp, domain.ErrPokemonNotFound, previous.Validators)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch pokemon from API: %w", err)
+		return nil, nil, err
+	}
+
+	if notModified {
+		log.Printf("Upstream NOT MODIFIED for %s", url)
+		r.caches.upstream.Set(url, previous)
+		r.caches.pokemonMoves.Set(previous.Pokemon.ID, previous.Moves)
+		return previous.Pokemon, previous.Moves, nil
+	}
+
+	// El learnset llega en la misma respuesta; se guarda aparte para no
+	// inflar la respuesta de /pokemon con cientos de movimientos.
+	moves := mapToDomainMoves(pokeAPIResp.Moves)
+	r.caches.pokemonMoves.Set(pokeAPIResp.ID, moves)
+
+	pokemon := r.mapToDomainPokemon(&pokeAPIResp)
+	if !validators.empty() {
+		r.caches.upstream.Set(url, upstreamPokemon{
+			Validators: validators,
+			Pokemon:    pokemon,
+			Moves:      moves,
+		})
+	}
+	return pokemon, moves, nil
+}
+
+// getJSON descarga url y decodifica el cuerpo en v. Un 404 se traduce en
+// notFound y se recuerda durante el TTL negativo, para que las búsquedas
+// repetidas de nombres inexistentes no lleguen a PokeAPI.
+func (r *pokeAPIRepository) getJSON(url string, v interface{}, notFound error) error {
+	_, _, err := r.fetchJSON(url, v, notFound, upstreamValidators{})
+	return err
+}
+
+// fetchJSON es getJSON con petición condicional: si previous tiene
+// validadores se envían y un 304 devuelve notModified sin tocar v.
+func (r *pokeAPIRepository) fetchJSON(url string, v interface{}, notFound error, previous upstreamValidators) (upstreamValidators, bool, error) {
+	var validators upstreamValidators
+	if _, missing := r.caches.notFound.Get(url); missing {
+		log.Printf("Cache HIT for not found: %s", url)
+		return validators, false, notFound
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return validators, false, err
+	}
+	previous.apply(req)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return validators, false, fmt.Errorf("failed to fetch %s from API: %w", url, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && !previous.empty() {
+		return previous, true, nil
+	}
+
 	if resp.StatusCode == http.StatusNotFound {
-		return nil, domain.ErrPokemonNotFound
+		r.caches.notFound.Set(url, true)
+		return validators, false, notFound
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return validators, false, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
 
-	var pokeAPIResp PokeAPIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&pokeAPIResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return validators, false, fmt.Errorf("failed to decode response: %w", err)
 	}
-
-	return r.mapToDomainPokemon(&pokeAPIResp), nil
+	return validatorsFrom(resp.Header), false, nil
 }
 
 func (r *pokeAPIRepository) fetchPokemonAll(url string) (*domain.PokemonList, error) {
@@ -215,7 +336,7 @@ func (r *pokeAPIRepository) fetchPokemonAll(url string) (*domain.PokemonList, er
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 	for _, t := range PokeAPIResponseList.Results {
-		pokemon, err := r.fetchPokemon(t.URL)
+		pokemon, _, err := r.fetchPokemon(t.URL)
 		if err != nil {
 			return nil, err
 		}
@@ -231,7 +352,9 @@ func (r *pokeAPIRepository) fetchPokemonAll(url string) (*domain.PokemonList, er
 }
 
 func (r *pokeAPIRepository) mapToDomainPokemon(apiPokemon *PokeAPIResponse) *domain.Pokemon {
-	now := time.Now()
+	// Solo se mapea al descargar de PokeAPI; la fecha viaja con el valor en
+	// caché y es la que se publica como Last-Modified.
+	fetchedAt := time.Now()
 
 	types := make([]domain.Type, len(apiPokemon.Types))
 	for i, t := range apiPokemon.Types {
@@ -268,6 +391,14 @@ func (r *pokeAPIRepository) mapToDomainPokemon(apiPokemon *PokeAPIResponse) *dom
 		}
 	}
 
+	forms := make([]domain.FormInfo, len(apiPokemon.Forms))
+	for i, f := range apiPokemon.Forms {
+		forms[i] = domain.FormInfo{
+			Name: f.Name,
+			URL:  f.URL,
+		}
+	}
+
 	return &domain.Pokemon{
 		ID: apiPokemon.ID,
 		Name:      apiPokemon.Name,
@@ -281,10 +412,23 @@ func (r *pokeAPIRepository) mapToDomainPokemon(apiPokemon *PokeAPIResponse) *dom
 			FrontShiny:   apiPokemon.Sprites.FrontShiny,
 			BackDefault:  apiPokemon.Sprites.BackDefault,
 			BackShiny:    apiPokemon.Sprites.BackShiny,
+
+			FrontFemale:      apiPokemon.Sprites.FrontFemale,
+			FrontShinyFemale: apiPokemon.Sprites.FrontShinyFemale,
+			BackFemale:       apiPokemon.Sprites.BackFemale,
+			BackShinyFemale:  apiPokemon.Sprites.BackShinyFemale,
+			Other:            apiPokemon.Sprites.Other,
+			Versions:         apiPokemon.Sprites.Versions,
 		},
 		Stats:     stats,
-		CreatedAt: now,
-		UpdatedAt: now,
+		HeldItems: mapToDomainHeldItems(apiPokemon.HeldItems),
+		Species: domain.SpeciesRef{
+			Name: apiPokemon.Species.Name,
+			URL:  apiPokemon.Species.URL,
+		},
+		Forms:     forms,
+		CreatedAt: fetchedAt,
+		UpdatedAt: fetchedAt,
 		PokeAPIID: apiPokemon.ID,
 	}
 }
@@ -0,0 +1,194 @@
+package infrastructure
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPokeAPIRepository_NegativeCache(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	repo, err := newPokeAPIRepository(server.URL+"/api/v2", http.DefaultTransport)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := repo.GetPokemonByName("missingno")
+		assert.Equal(t, domain.ErrPokemonNotFound, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+	_, err = repo.GetMoveByName("missingno")
+	assert.Equal(t, domain.ErrMoveNotFound, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestPokeAPIRepository_UpstreamRevalidation(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	t.Setenv("CACHE_TTL_POKEMON", "1ms")
+	var full, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":25,"name":"pikachu","moves":[{"move":{"name":"thunderbolt"}}]}`))
+	}))
+	defer server.Close()
+
+	repo, err := newPokeAPIRepository(server.URL+"/api/v2", http.DefaultTransport)
+	require.NoError(t, err)
+
+	first, err := repo.GetPokemonByID(25)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	second, err := repo.GetPokemonByID(25)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&full))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+	assert.Equal(t, first.Name, second.Name)
+	assert.True(t, first.UpdatedAt.Equal(second.UpdatedAt))
+
+	moves, err := repo.GetPokemonMoves(25)
+	require.NoError(t, err)
+	assert.Equal(t, "thunderbolt", moves[0].Move.Name)
+}
+
+func TestPokeAPIRepository_StaleCacheIsBounded(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	t.Setenv("CACHE_TTL_POKEMON", "1ms")
+	t.Setenv("CACHE_STALE_SIZE", "1")
+	var full int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":1,"name":"bulbasaur"}`))
+	}))
+	defer server.Close()
+
+	repo, err := newPokeAPIRepository(server.URL+"/api/v2", http.DefaultTransport)
+	require.NoError(t, err)
+	shared := NewMemoryCache()
+	repo.caches = newRepositoryCaches(shared, cacheTTLsFromEnv())
+
+	_, err = repo.GetPokemonByID(1)
+	require.NoError(t, err)
+	_, err = repo.GetPokemonByID(2)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	// Solo la última respuesta sigue guardada para revalidar.
+	_, err = repo.GetPokemonByID(2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&full))
+	_, err = repo.GetPokemonByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&full))
+
+	_, found := shared.Get("upstream:" + server.URL + "/api/v2/pokemon/1")
+	assert.False(t, found)
+}
+
+func TestPokeAPIRepository_GetPokemonSummaries(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		assert.Equal(t, "/api/v2/pokemon", r.URL.Path)
+		assert.Equal(t, "offset=20&limit=2", r.URL.RawQuery)
+		w.Write([]byte(`{"count":1302,"next":"n","previous":"p","results":[
+			{"name":"spearow","url":"https://pokeapi.co/api/v2/pokemon/21/"},
+			{"name":"fearow","url":"https://pokeapi.co/api/v2/pokemon/22/"}]}`))
+	}))
+	defer server.Close()
+
+	repo, err := newPokeAPIRepository(server.URL+"/api/v2", http.DefaultTransport)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		list, err := repo.GetPokemonSummaries(domain.PokemonFilter{Offset: 20, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 1302, list.Count)
+		assert.Equal(t, []domain.PokemonSummary{{ID: 21, Name: "spearow"}, {ID: 22, Name: "fearow"}}, list.Pokemons)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestPokeAPIRepository_GetPokemonMoves(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":25,"name":"pikachu","moves":[{"move":{"name":"thunderbolt"}}]}`))
+	}))
+	defer server.Close()
+
+	repo, err := newPokeAPIRepository(server.URL+"/api/v2", http.DefaultTransport)
+	require.NoError(t, err)
+	// Un backend que no conserva nada: el learnset debe salir de la respuesta.
+	repo.caches.pokemonMoves = NewCache[int, []domain.PokemonMove](NewLRUCache(1, -time.Second), "pokemon:moves:", time.Hour)
+
+	moves, err := repo.GetPokemonMoves(25)
+
+	require.NoError(t, err)
+	require.Len(t, moves, 1)
+	assert.Equal(t, "thunderbolt", moves[0].Move.Name)
+}
+
+func TestPokeAPIRepository_UpdatedAt(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/move/tackle":
+			w.Write([]byte(`{"id":33,"name":"tackle"}`))
+		case "/api/v2/pokemon/25/encounters":
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	repo, err := newPokeAPIRepository(server.URL+"/api/v2", http.DefaultTransport)
+	require.NoError(t, err)
+
+	t.Run("Success - resources keep their fetch time through the cache", func(t *testing.T) {
+		fetched, err := repo.GetMoveByName("tackle")
+		require.NoError(t, err)
+		cached, err := repo.GetMoveByName("tackle")
+		require.NoError(t, err)
+
+		assert.False(t, fetched.UpdatedAt.IsZero())
+		assert.True(t, fetched.UpdatedAt.Equal(cached.UpdatedAt))
+	})
+
+	t.Run("Success - encounters keep their fetch time through the cache", func(t *testing.T) {
+		_, fetchedAt, err := repo.GetPokemonEncounters(25)
+		require.NoError(t, err)
+		_, cachedAt, err := repo.GetPokemonEncounters(25)
+		require.NoError(t, err)
+
+		assert.False(t, fetchedAt.IsZero())
+		assert.True(t, fetchedAt.Equal(cachedAt))
+	})
+}
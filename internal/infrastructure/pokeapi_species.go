@@ -0,0 +1,57 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type PokeAPISpeciesResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Varieties []struct {
+		IsDefault bool          `json:"is_default"`
+		Pokemon   PokeAPIResult `json:"pokemon"`
+	} `json:"varieties"`
+	Names             []PokeAPIName       `json:"names"`
+	Genera            []PokeAPIGenus      `json:"genera"`
+	FlavorTextEntries []PokeAPIFlavorText `json:"flavor_text_entries"`
+}
+
+func (r *pokeAPIRepository) GetPokemonSpecies(id int) (*domain.PokemonSpecies, error) {
+	if cached, found := r.caches.species.Get(id); found {
+		log.Printf("Cache HIT for pokemon species ID: %d", id)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for pokemon species ID: %d", id)
+	var apiSpecies PokeAPISpeciesResponse
+	url := fmt.Sprintf("%s/pokemon-species/%d", r.baseURL, id)
+	if err := r.getJSON(url, &apiSpecies, domain.ErrPokemonNotFound); err != nil {
+		return nil, err
+	}
+
+	species := mapToDomainSpecies(&apiSpecies)
+	r.caches.species.Set(id, species)
+	return species, nil
+}
+
+func mapToDomainSpecies(apiSpecies *PokeAPISpeciesResponse) *domain.PokemonSpecies {
+	species := &domain.PokemonSpecies{
+		ID:          apiSpecies.ID,
+		Name:        apiSpecies.Name,
+		Varieties:   make([]domain.Variety, len(apiSpecies.Varieties)),
+		Names:       mapLocalizedNames(apiSpecies.Names),
+		Genera:      mapLocalizedGenera(apiSpecies.Genera),
+		FlavorTexts: mapFlavorTexts(apiSpecies.FlavorTextEntries),
+	}
+	for i, v := range apiSpecies.Varieties {
+		species.Varieties[i] = domain.Variety{
+			Name:      v.Pokemon.Name,
+			URL:       v.Pokemon.URL,
+			IsDefault: v.IsDefault,
+		}
+	}
+	return species
+}
@@ -0,0 +1,94 @@
+package infrastructure
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+func (r *pokeAPIRepository) GetSpriteImage(url string, size int, format string) (*domain.SpriteImage, error) {
+	key := r.sprites.key(url, size, format)
+	if img, found := r.sprites.Get(key); found {
+		log.Printf("Sprite cache HIT: %s (size %d, format %q)", url, size, format)
+		return img, nil
+	}
+
+	log.Printf("Sprite cache MISS: %s (size %d, format %q)", url, size, format)
+	original, err := r.originalSprite(url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo se convierten PNG; los GIF animados de showdown y los SVG de
+	// dream-world se sirven tal cual.
+	if original.ContentType != "image/png" || (size == 0 && format != domain.SpriteFormatWebP) {
+		return original, nil
+	}
+
+	data, contentType, err := convertPNG(original.Data, size, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert sprite: %w", err)
+	}
+
+	img := &domain.SpriteImage{
+		Data:         data,
+		ContentType:  contentType,
+		ETag:         domain.StrongETag(data),
+		LastModified: original.LastModified,
+	}
+	if err := r.sprites.Put(key, img); err != nil {
+		log.Printf("Failed to store converted sprite %s: %v", url, err)
+	}
+	return img, nil
+}
+
+func (r *pokeAPIRepository) originalSprite(url string) (*domain.SpriteImage, error) {
+	key := r.sprites.key(url, 0, "")
+	if img, found := r.sprites.Get(key); found {
+		return img, nil
+	}
+
+	resp, err := r.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sprite: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrSpriteNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sprite host returned status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sprite: %w", err)
+	}
+
+	// El ETag se calcula sobre los bytes en vez de reutilizar el del host: los
+	// CDN suelen enviarlo débil (W/"...") y notModified nunca lo casaría.
+	img := &domain.SpriteImage{
+		Data:         data,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ETag:         domain.StrongETag(data),
+		LastModified: time.Now().UTC(),
+	}
+	// Algunos espejos de los sprites los sirven como text/plain u octet-stream.
+	if !strings.HasPrefix(img.ContentType, "image/") {
+		img.ContentType = http.DetectContentType(data)
+	}
+	if lm, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		img.LastModified = lm
+	}
+
+	if err := r.sprites.Put(key, img); err != nil {
+		log.Printf("Failed to store sprite %s: %v", url, err)
+	}
+	return img, nil
+}
@@ -0,0 +1,67 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+type PokeAPITypeResponse struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageTo   []PokeAPIResult `json:"double_damage_to"`
+		HalfDamageTo     []PokeAPIResult `json:"half_damage_to"`
+		NoDamageTo       []PokeAPIResult `json:"no_damage_to"`
+		DoubleDamageFrom []PokeAPIResult `json:"double_damage_from"`
+		HalfDamageFrom   []PokeAPIResult `json:"half_damage_from"`
+		NoDamageFrom     []PokeAPIResult `json:"no_damage_from"`
+	} `json:"damage_relations"`
+	Pokemon []struct {
+		Pokemon PokeAPIResult `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+func (r *pokeAPIRepository) GetTypeByName(name string) (*domain.TypeDetail, error) {
+	if cached, found := r.caches.types.Get(name); found {
+		log.Printf("Cache HIT for type name: %s", name)
+		return cached, nil
+	}
+
+	log.Printf("Cache MISS for type name: %s", name)
+	var apiType PokeAPITypeResponse
+	url := fmt.Sprintf("%s/type/%s", r.baseURL, name)
+	if err := r.getJSON(url, &apiType, domain.ErrTypeNotFound); err != nil {
+		return nil, err
+	}
+
+	relations := apiType.DamageRelations
+	typeDetail := &domain.TypeDetail{
+		ID:   apiType.ID,
+		Name: apiType.Name,
+		DamageRelations: domain.DamageRelations{
+			DoubleDamageTo:   resultNames(relations.DoubleDamageTo),
+			HalfDamageTo:     resultNames(relations.HalfDamageTo),
+			NoDamageTo:       resultNames(relations.NoDamageTo),
+			DoubleDamageFrom: resultNames(relations.DoubleDamageFrom),
+			HalfDamageFrom:   resultNames(relations.HalfDamageFrom),
+			NoDamageFrom:     resultNames(relations.NoDamageFrom),
+		},
+		Pokemon: make([]string, len(apiType.Pokemon)),
+	}
+	for i, p := range apiType.Pokemon {
+		typeDetail.Pokemon[i] = p.Pokemon.Name
+	}
+
+	r.caches.types.Set(name, typeDetail)
+	return typeDetail, nil
+}
+
+func resultNames(results []PokeAPIResult) []string {
+	names := make([]string, len(results))
+	for i, r := range results {
+		names[i] = r.Name
+	}
+	return names
+}
@@ -0,0 +1,444 @@
+package infrastructure
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	redisPoolSize    = 8
+	redisDialTimeout = 5 * time.Second
+	redisIOTimeout   = 2 * time.Second
+	redisScanCount   = "100"
+
+	// redisInvalidationChannel es el canal de pub/sub por el que TieredCache
+	// avisa a las demás instancias de las claves escritas o borradas.
+	redisInvalidationChannel = "reto-pokemon-api:cache:invalidate"
+	redisResubscribeDelay    = time.Second
+)
+
+// RedisCache comparte la caché entre instancias usando el protocolo RESP de
+// Redis. Los valores llegan ya serializados en JSON, con las mismas claves que
+// la caché en memoria, y la expiración la aplica Redis con PX.
+type RedisCache struct {
+	addr     string
+	password string
+	db       int
+	pool     chan *redisConn
+}
+
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// redisError es una respuesta de error de Redis; a diferencia de un error de
+// red, la conexión sigue siendo válida.
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+
+func NewRedisCache(addr, password string, db int) (*RedisCache, error) {
+	cache := &RedisCache{
+		addr:     addr,
+		password: password,
+		db:       db,
+		pool:     make(chan *redisConn, redisPoolSize),
+	}
+
+	if _, err := cache.do("PING"); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", addr, err)
+	}
+	return cache, nil
+}
+
+func (c *RedisCache) Set(key string, value []byte, ttl time.Duration) {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+	if _, err := c.do(args...); err != nil {
+		log.Printf("Failed to write cache entry %s: %v", key, err)
+	}
+}
+
+func (c *RedisCache) Get(key string) ([]byte, bool) {
+	reply, err := c.do("GET", key)
+	if err != nil {
+		log.Printf("Failed to read cache entry %s: %v", key, err)
+		return nil, false
+	}
+
+	raw, ok := reply.(string)
+	if !ok {
+		return nil, false
+	}
+	return []byte(raw), true
+}
+
+func (c *RedisCache) Delete(key string) {
+	if _, err := c.do("DEL", key); err != nil {
+		log.Printf("Failed to delete cache entry %s: %v", key, err)
+	}
+}
+
+// Clear borra solo las claves de esta caché, ya que la base de datos de Redis
+// puede estar compartida con otros servicios.
+func (c *RedisCache) Clear() {
+	keys, err := c.keys()
+	if err != nil {
+		log.Printf("Failed to list cache entries: %v", err)
+		return
+	}
+
+	for start := 0; start < len(keys); start += 100 {
+		batch := keys[start:min(start+100, len(keys))]
+		if _, err := c.do(append([]string{"DEL"}, batch...)...); err != nil {
+			log.Printf("Failed to clear cache entries: %v", err)
+			return
+		}
+	}
+}
+
+func (c *RedisCache) Size() int {
+	keys, err := c.keys()
+	if err != nil {
+		log.Printf("Failed to list cache entries: %v", err)
+		return 0
+	}
+	return len(keys)
+}
+
+// AddMember, RemoveMember y Members guardan conjuntos con SADD, SREM y
+// SMEMBERS, que Redis aplica de forma atómica aunque escriban varias
+// instancias a la vez.
+func (c *RedisCache) AddMember(key, member string) error {
+	_, err := c.do("SADD", key, member)
+	return err
+}
+
+func (c *RedisCache) RemoveMember(key, member string) error {
+	_, err := c.do("SREM", key, member)
+	return err
+}
+
+func (c *RedisCache) Members(key string) ([]string, error) {
+	reply, err := c.do("SMEMBERS", key)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := reply.([]interface{})
+	if !ok {
+		return nil, errors.New("unexpected SMEMBERS reply")
+	}
+	members := make([]string, 0, len(items))
+	for _, item := range items {
+		if member, ok := item.(string); ok {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// Publish difunde un mensaje de invalidación a todas las instancias suscritas.
+func (c *RedisCache) Publish(message string) {
+	if _, err := c.do("PUBLISH", redisInvalidationChannel, message); err != nil {
+		log.Printf("Failed to publish cache invalidation %q: %v", message, err)
+	}
+}
+
+// Subscribe abre una conexión dedicada al canal de invalidación y llama a
+// handle con cada mensaje recibido. Vuelve cuando la suscripción está activa; si
+// la conexión se cae se reconecta en segundo plano hasta que se llama a la
+// función devuelta, que cierra la conexión y termina la goroutine.
+func (c *RedisCache) Subscribe(handle func(key string)) (func(), error) {
+	conn, err := c.subscribe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to cache invalidations: %w", err)
+	}
+
+	sub := &redisSubscription{conn: conn, done: make(chan struct{})}
+	go sub.run(c, handle)
+	return sub.close, nil
+}
+
+type redisSubscription struct {
+	mu   sync.Mutex
+	conn *redisConn
+	done chan struct{}
+	once sync.Once
+}
+
+func (s *redisSubscription) run(c *RedisCache, handle func(key string)) {
+	conn := s.conn
+	for {
+		err := conn.receive(handle)
+		conn.conn.Close()
+		if s.closed() {
+			return
+		}
+		log.Printf("Cache invalidation subscription lost: %v", err)
+
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-time.After(redisResubscribeDelay):
+			}
+			if conn, err = c.subscribe(); err == nil {
+				break
+			}
+		}
+
+		s.mu.Lock()
+		if s.closed() {
+			s.mu.Unlock()
+			conn.conn.Close()
+			return
+		}
+		s.conn = conn
+		s.mu.Unlock()
+
+		// Durante la desconexión se pudieron perder avisos.
+		handle(invalidateAll)
+	}
+}
+
+func (s *redisSubscription) closed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// close desbloquea receive cerrando la conexión actual.
+func (s *redisSubscription) close() {
+	s.once.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		close(s.done)
+		s.conn.conn.Close()
+	})
+}
+
+func (c *RedisCache) subscribe() (*redisConn, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do("SUBSCRIBE", redisInvalidationChannel)
+	if err == nil {
+		if replyErr, ok := reply.(redisError); ok {
+			err = replyErr
+		}
+	}
+	if err != nil {
+		conn.conn.Close()
+		return nil, err
+	}
+
+	// Los mensajes pueden tardar indefinidamente en llegar.
+	if err := conn.conn.SetDeadline(time.Time{}); err != nil {
+		conn.conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// receive procesa los mensajes ["message", canal, clave] hasta que falla la
+// conexión.
+func (rc *redisConn) receive(handle func(key string)) error {
+	for {
+		reply, err := readRESP(rc.r)
+		if err != nil {
+			return err
+		}
+
+		msg, ok := reply.([]interface{})
+		if !ok || len(msg) != 3 || msg[0] != "message" {
+			continue
+		}
+		if key, ok := msg[2].(string); ok {
+			handle(key)
+		}
+	}
+}
+
+// keys recorre con SCAN cada espacio de nombres de cacheNamespaces.
+func (c *RedisCache) keys() ([]string, error) {
+	var keys []string
+	for _, prefix := range cacheNamespaces {
+		cursor := "0"
+		for {
+			reply, err := c.do("SCAN", cursor, "MATCH", prefix+"*", "COUNT", redisScanCount)
+			if err != nil {
+				return nil, err
+			}
+
+			page, ok := reply.([]interface{})
+			if !ok || len(page) != 2 {
+				return nil, errors.New("unexpected SCAN reply")
+			}
+			cursor, _ = page[0].(string)
+			found, _ := page[1].([]interface{})
+			for _, k := range found {
+				if key, ok := k.(string); ok {
+					keys = append(keys, key)
+				}
+			}
+
+			if cursor == "0" || cursor == "" {
+				break
+			}
+		}
+	}
+	return keys, nil
+}
+
+// do ejecuta un comando con una conexión del pool. Las conexiones que fallan
+// por red o protocolo se descartan en lugar de devolverse al pool.
+func (c *RedisCache) do(args ...string) (interface{}, error) {
+	conn, err := c.conn()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(args...)
+	if err != nil {
+		conn.conn.Close()
+		return nil, err
+	}
+
+	select {
+	case c.pool <- conn:
+	default:
+		conn.conn.Close()
+	}
+
+	if replyErr, ok := reply.(redisError); ok {
+		return nil, replyErr
+	}
+	return reply, nil
+}
+
+func (c *RedisCache) conn() (*redisConn, error) {
+	select {
+	case conn := <-c.pool:
+		return conn, nil
+	default:
+	}
+	return c.dial()
+}
+
+func (c *RedisCache) dial() (*redisConn, error) {
+	netConn, err := net.DialTimeout("tcp", c.addr, redisDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{conn: netConn, r: bufio.NewReader(netConn)}
+
+	if c.password != "" {
+		if err := conn.expectOK("AUTH", c.password); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	if c.db != 0 {
+		if err := conn.expectOK("SELECT", strconv.Itoa(c.db)); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (rc *redisConn) expectOK(args ...string) error {
+	reply, err := rc.do(args...)
+	if err != nil {
+		return err
+	}
+	if replyErr, ok := reply.(redisError); ok {
+		return replyErr
+	}
+	return nil
+}
+
+func (rc *redisConn) do(args ...string) (interface{}, error) {
+	if err := rc.conn.SetDeadline(time.Now().Add(redisIOTimeout)); err != nil {
+		return nil, err
+	}
+
+	w := bufio.NewWriter(rc.conn)
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	return readRESP(rc.r)
+}
+
+// readRESP lee una respuesta RESP: las cadenas simples y bulk se devuelven como
+// string, los enteros como int64, los arrays como []interface{} y el bulk nulo
+// como nil.
+func readRESP(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed RESP line %q", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return redisError(payload), nil
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, size)
+		for i := range items {
+			if items[i], err = readRESP(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unknown RESP type %q", kind)
+	}
+}
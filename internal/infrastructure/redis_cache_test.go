@@ -0,0 +1,211 @@
+package infrastructure
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis implementa en proceso el subconjunto de comandos RESP que usa
+// RedisCache.
+type fakeRedis struct {
+	mu          sync.Mutex
+	values      map[string]string
+	expires     map[string]time.Time
+	sets        map[string]map[string]bool
+	subscribers map[string][]net.Conn
+}
+
+func startFakeRedis(t *testing.T) (string, *fakeRedis) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeRedis{
+		values:      map[string]string{},
+		expires:     map[string]time.Time{},
+		sets:        map[string]map[string]bool{},
+		subscribers: map[string][]net.Conn{},
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return listener.Addr().String(), server
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		reply, err := readRESP(r)
+		if err != nil {
+			return
+		}
+		items, _ := reply.([]interface{})
+		args := make([]string, len(items))
+		for i, item := range items {
+			args[i], _ = item.(string)
+		}
+		fmt.Fprint(conn, s.exec(conn, args))
+	}
+}
+
+func (s *fakeRedis) exec(conn net.Conn, args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "SET":
+		s.values[args[1]] = args[2]
+		delete(s.expires, args[1])
+		if len(args) == 5 && strings.EqualFold(args[3], "PX") {
+			ms, _ := strconv.Atoi(args[4])
+			s.expires[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		return "+OK\r\n"
+	case "GET":
+		value, ok := s.get(args[1])
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := s.values[key]; ok {
+				delete(s.values, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	case "SCAN":
+		var keys []string
+		for key := range s.values {
+			if matched, _ := path.Match(args[3], key); matched {
+				keys = append(keys, key)
+			}
+		}
+		reply := fmt.Sprintf("*2\r\n$1\r\n0\r\n*%d\r\n", len(keys))
+		for _, key := range keys {
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(key), key)
+		}
+		return reply
+	case "SADD":
+		if s.sets[args[1]] == nil {
+			s.sets[args[1]] = map[string]bool{}
+		}
+		s.sets[args[1]][args[2]] = true
+		return ":1\r\n"
+	case "SREM":
+		delete(s.sets[args[1]], args[2])
+		return ":1\r\n"
+	case "SMEMBERS":
+		reply := fmt.Sprintf("*%d\r\n", len(s.sets[args[1]]))
+		for member := range s.sets[args[1]] {
+			reply += fmt.Sprintf("$%d\r\n%s\r\n", len(member), member)
+		}
+		return reply
+	case "SUBSCRIBE":
+		s.subscribers[args[1]] = append(s.subscribers[args[1]], conn)
+		return fmt.Sprintf("*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:1\r\n", len(args[1]), args[1])
+	case "PUBLISH":
+		for _, sub := range s.subscribers[args[1]] {
+			fmt.Fprintf(sub, "*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n",
+				len(args[1]), args[1], len(args[2]), args[2])
+		}
+		return fmt.Sprintf(":%d\r\n", len(s.subscribers[args[1]]))
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+func (s *fakeRedis) get(key string) (string, bool) {
+	if expiresAt, ok := s.expires[key]; ok && time.Now().After(expiresAt) {
+		delete(s.values, key)
+		delete(s.expires, key)
+	}
+	value, ok := s.values[key]
+	return value, ok
+}
+
+func (s *fakeRedis) raw(key string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(key)
+}
+
+func TestRedisCache(t *testing.T) {
+	addr, server := startFakeRedis(t)
+
+	cache, err := NewRedisCache(addr, "", 0)
+	require.NoError(t, err)
+
+	t.Run("Success - values are shared as JSON under the same keys", func(t *testing.T) {
+		NewCache[int, *domain.Pokemon](cache, "pokemon:id:", time.Hour).Set(25, &domain.Pokemon{ID: 25, Name: "pikachu"})
+
+		raw, _ := server.raw("pokemon:id:25")
+		assert.Contains(t, raw, `"name":"pikachu"`)
+
+		other, err := NewRedisCache(addr, "", 0)
+		require.NoError(t, err)
+		pokemon, found := NewCache[int, *domain.Pokemon](other, "pokemon:id:", time.Hour).Get(25)
+		require.True(t, found)
+		assert.Equal(t, 25, pokemon.ID)
+	})
+
+	t.Run("Success - Redis applies the TTL", func(t *testing.T) {
+		cache.Set("berry:name:cheri", []byte(`{"name":"cheri"}`), time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+
+		_, found := cache.Get("berry:name:cheri")
+		assert.False(t, found)
+	})
+
+	t.Run("Success - a TTL of zero is stored without expiry", func(t *testing.T) {
+		cache.Set("team:id:abc", []byte(`{"name":"kanto"}`), 0)
+		time.Sleep(5 * time.Millisecond)
+
+		_, found := cache.Get("team:id:abc")
+		assert.True(t, found)
+	})
+
+	t.Run("Success - clear keeps foreign keys", func(t *testing.T) {
+		server.exec(nil, []string{"SET", "other-service:key", "value"})
+		cache.Set("move:name:tackle", []byte(`{"name":"tackle"}`), time.Hour)
+		assert.Equal(t, 2, cache.Size())
+
+		cache.Clear()
+
+		assert.Equal(t, 0, cache.Size())
+		_, found := server.raw("other-service:key")
+		assert.True(t, found)
+		_, found = server.raw("team:id:abc")
+		assert.True(t, found)
+	})
+
+	t.Run("Error - unreachable server", func(t *testing.T) {
+		_, err := NewRedisCache("127.0.0.1:1", "", 0)
+
+		assert.Error(t, err)
+	})
+}
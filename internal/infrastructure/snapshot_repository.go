@@ -0,0 +1,231 @@
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+// snapshotBaseURL es la URL base ficticia que usa el repositorio en modo
+// offline; el transporte del snapshot atiende todas las peticiones a este host.
+const (
+	snapshotHost    = "snapshot.local"
+	snapshotBaseURL = "http://" + snapshotHost + "/api/v2"
+)
+
+// NewSnapshotRepository crea un repositorio que lee un snapshot local con el
+// formato de PokeAPI/api-data (data/api/v2/<recurso>/<id>/index.json), ya sea
+// un directorio o un archivo .zip. Reutiliza todo el mapeo del repositorio
+// HTTP cambiando únicamente el transporte, así que no necesita red.
+func NewSnapshotRepository(snapshotPath string) (domain.PokeAPIRepository, error) {
+	fsys, err := openSnapshot(snapshotPath)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := findSnapshotRoot(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Serving PokeAPI data from snapshot %s (root %s)", snapshotPath, root)
+	return newPokeAPIRepository(snapshotBaseURL, newSnapshotTransport(fsys, root))
+}
+
+func openSnapshot(snapshotPath string) (fs.FS, error) {
+	info, err := os.Stat(snapshotPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+
+	if info.IsDir() {
+		return os.DirFS(snapshotPath), nil
+	}
+
+	archive, err := zip.OpenReader(snapshotPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot archive: %w", err)
+	}
+	return archive, nil
+}
+
+// findSnapshotRoot localiza el directorio equivalente a /api/v2, que puede
+// estar en la raíz, en data/api/v2 o dentro de una carpeta de primer nivel
+// como la que genera GitHub al descargar api-data en zip.
+func findSnapshotRoot(fsys fs.FS) (string, error) {
+	candidates := []string{".", "api/v2", "data/api/v2"}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return "", fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			candidates = append(candidates, path.Join(e.Name(), "api/v2"), path.Join(e.Name(), "data/api/v2"))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := fs.Stat(fsys, path.Join(candidate, "pokemon/index.json")); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", errors.New("snapshot does not contain pokemon/index.json")
+}
+
+type snapshotTransport struct {
+	fsys fs.FS
+	root string
+
+	mu    sync.Mutex
+	names map[string]map[string]string
+}
+
+func newSnapshotTransport(fsys fs.FS, root string) *snapshotTransport {
+	return &snapshotTransport{
+		fsys:  fsys,
+		root:  root,
+		names: make(map[string]map[string]string),
+	}
+}
+
+func (t *snapshotTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Cualquier otro host (por ejemplo los sprites de GitHub) no existe offline.
+	if req.URL.Host != snapshotHost {
+		return snapshotResponse(req, http.StatusNotFound, nil), nil
+	}
+
+	resourcePath := strings.Trim(strings.TrimPrefix(req.URL.Path, "/api/v2"), "/")
+	segments := strings.Split(resourcePath, "/")
+
+	var (
+		body []byte
+		err  error
+	)
+	if len(segments) == 1 {
+		body, err = t.list(segments[0], req)
+	} else {
+		body, err = t.resource(segments)
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return snapshotResponse(req, http.StatusNotFound, nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// api-data usa URLs relativas (/api/v2/pokemon/1/); se reescriben para que
+	// el repositorio pueda seguirlas igual que las de la API real.
+	body = bytes.ReplaceAll(body, []byte(`"/api/v2/`), []byte(`"`+snapshotBaseURL+`/`))
+	return snapshotResponse(req, http.StatusOK, body), nil
+}
+
+func (t *snapshotTransport) resource(segments []string) ([]byte, error) {
+	if _, err := strconv.Atoi(segments[1]); err != nil {
+		id, err := t.resolveName(segments[0], segments[1])
+		if err != nil {
+			return nil, err
+		}
+		segments[1] = id
+	}
+
+	return fs.ReadFile(t.fsys, path.Join(t.root, path.Join(segments...), "index.json"))
+}
+
+// list sirve el índice de un recurso aplicando offset y limit, ya que
+// api-data guarda el listado completo en un único archivo.
+func (t *snapshotTransport) list(resource string, req *http.Request) ([]byte, error) {
+	var index PokeAPIResponseList
+	if err := t.readIndex(resource, &index); err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	total := len(index.Results)
+	start := min(max(offset, 0), total)
+	end := min(start+limit, total)
+
+	page := PokeAPIResponseList{
+		Count:   total,
+		Results: index.Results[start:end],
+	}
+	if end < total {
+		page.Next = fmt.Sprintf("%s/%s?offset=%d&limit=%d", snapshotBaseURL, resource, end, limit)
+	}
+	if start > 0 {
+		page.Previous = fmt.Sprintf("%s/%s?offset=%d&limit=%d", snapshotBaseURL, resource, max(start-limit, 0), limit)
+	}
+
+	return json.Marshal(page)
+}
+
+// resolveName traduce un nombre a su ID usando el índice del recurso, porque
+// api-data solo tiene directorios numéricos.
+func (t *snapshotTransport) resolveName(resource, name string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ids, loaded := t.names[resource]
+	if !loaded {
+		var index PokeAPIResponseList
+		if err := t.readIndex(resource, &index); err != nil {
+			return "", err
+		}
+
+		ids = make(map[string]string, len(index.Results))
+		for _, r := range index.Results {
+			if id, ok := domain.ResourceID(r.URL); ok {
+				ids[r.Name] = strconv.Itoa(id)
+			}
+		}
+		t.names[resource] = ids
+	}
+
+	id, ok := ids[name]
+	if !ok {
+		return "", fs.ErrNotExist
+	}
+	return id, nil
+}
+
+func (t *snapshotTransport) readIndex(resource string, v interface{}) error {
+	raw, err := fs.ReadFile(t.fsys, path.Join(t.root, resource, "index.json"))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func snapshotResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
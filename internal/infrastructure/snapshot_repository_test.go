@@ -0,0 +1,71 @@
+package infrastructure
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSnapshotFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	path := filepath.Join(root, "data", "api", "v2", name, "index.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestSnapshotRepository(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	root := t.TempDir()
+
+	writeSnapshotFile(t, root, "pokemon", `{"count":2,"next":null,"previous":null,"results":[
+		{"name":"bulbasaur","url":"/api/v2/pokemon/1/"},
+		{"name":"pikachu","url":"/api/v2/pokemon/25/"}]}`)
+	writeSnapshotFile(t, root, "pokemon/1", `{"id":1,"name":"bulbasaur","height":7,
+		"species":{"name":"bulbasaur","url":"/api/v2/pokemon-species/1/"}}`)
+	writeSnapshotFile(t, root, "pokemon/25", `{"id":25,"name":"pikachu","height":4,
+		"types":[{"slot":1,"type":{"name":"electric","url":"/api/v2/type/13/"}}],
+		"species":{"name":"pikachu","url":"/api/v2/pokemon-species/25/"}}`)
+	writeSnapshotFile(t, root, "pokemon/25/encounters", `[]`)
+
+	repo, err := NewSnapshotRepository(root)
+	require.NoError(t, err)
+
+	t.Run("Success - resolves names through the resource index", func(t *testing.T) {
+		pokemon, err := repo.GetPokemonByName("pikachu")
+
+		require.NoError(t, err)
+		assert.Equal(t, 25, pokemon.ID)
+		assert.Equal(t, "electric", pokemon.Types[0].Type.Name)
+		assert.Equal(t, snapshotBaseURL+"/pokemon-species/25/", pokemon.Species.URL)
+	})
+
+	t.Run("Success - paginates the list", func(t *testing.T) {
+		list, err := repo.GetPokemonAll(domain.PokemonFilter{Limit: 1, Offset: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, list.Count)
+		assert.Len(t, *list.Pokemons, 1)
+		assert.Equal(t, "pikachu", (*list.Pokemons)[0].Name)
+		assert.Empty(t, list.Next)
+		assert.NotEmpty(t, list.Previous)
+	})
+
+	t.Run("Success - nested resources", func(t *testing.T) {
+		encounters, fetchedAt, err := repo.GetPokemonEncounters(25)
+
+		require.NoError(t, err)
+		assert.Empty(t, encounters)
+		assert.False(t, fetchedAt.IsZero())
+	})
+
+	t.Run("Error - missing resource", func(t *testing.T) {
+		_, err := repo.GetPokemonByID(151)
+
+		assert.Equal(t, domain.ErrPokemonNotFound, err)
+	})
+}
@@ -0,0 +1,108 @@
+package infrastructure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+// spriteStore guarda en disco los sprites descargados (y sus versiones
+// redimensionadas) para no volver a pedirlos a GitHub en cada reinicio.
+type spriteStore struct {
+	dir string
+}
+
+type spriteMeta struct {
+	ContentType  string    `json:"content_type"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+func newSpriteStore(dir string) (*spriteStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create sprite cache dir %s: %w", dir, err)
+	}
+	return &spriteStore{dir: dir}, nil
+}
+
+// key identifica una variante del sprite; los PNG conservan la clave
+// "<hash>-<size>" de antes de existir los formatos.
+func (s *spriteStore) key(url string, size int, format string) string {
+	sum := sha256.Sum256([]byte(url))
+	key := fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:16]), size)
+	if format == domain.SpriteFormatWebP {
+		key += "-" + format
+	}
+	return key
+}
+
+func (s *spriteStore) Get(key string) (*domain.SpriteImage, bool) {
+	rawMeta, err := os.ReadFile(filepath.Join(s.dir, key+".json"))
+	if err != nil {
+		return nil, false
+	}
+
+	var meta spriteMeta
+	if err := json.Unmarshal(rawMeta, &meta); err != nil {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, key+".bin"))
+	if err != nil {
+		return nil, false
+	}
+
+	// Las entradas antiguas pueden guardar el ETag débil del host.
+	if !strings.HasPrefix(meta.ETag, `"`) {
+		meta.ETag = domain.StrongETag(data)
+	}
+
+	return &domain.SpriteImage{
+		Data:         data,
+		ContentType:  meta.ContentType,
+		ETag:         meta.ETag,
+		LastModified: meta.LastModified,
+	}, true
+}
+
+// Put escribe primero los bytes y después los metadatos; Get solo considera
+// válida una entrada con metadatos, así que una escritura a medias no se sirve.
+func (s *spriteStore) Put(key string, img *domain.SpriteImage) error {
+	if err := writeFileAtomic(filepath.Join(s.dir, key+".bin"), img.Data); err != nil {
+		return err
+	}
+
+	rawMeta, err := json.Marshal(spriteMeta{
+		ContentType:  img.ContentType,
+		ETag:         img.ETag,
+		LastModified: img.LastModified,
+	})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(s.dir, key+".json"), rawMeta)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,228 @@
+package infrastructure
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+func encodeTestPNG(t *testing.T, w, h int, fill func(x, y int) color.Color) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, fill(x, y))
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestConvertPNG(t *testing.T) {
+	red := color.NRGBA{R: 255, A: 255}
+	// Mitad izquierda roja y opaca, mitad derecha transparente.
+	halfRed := encodeTestPNG(t, 96, 48, func(x, y int) color.Color {
+		if x < 48 {
+			return red
+		}
+		return color.NRGBA{}
+	})
+
+	tests := []struct {
+		name        string
+		size        int
+		format      string
+		contentType string
+		width       int
+		height      int
+	}{
+		{name: "Success - downscales keeping the aspect ratio", size: 32, contentType: "image/png", width: 32, height: 16},
+		{name: "Success - upscales", size: 192, format: domain.SpriteFormatPNG, contentType: "image/png", width: 192, height: 96},
+		{name: "Success - converts to WebP without resizing", format: domain.SpriteFormatWebP, contentType: "image/webp", width: 96, height: 48},
+		{name: "Success - resizes and converts to WebP", size: 48, format: domain.SpriteFormatWebP, contentType: "image/webp", width: 48, height: 24},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, contentType, err := convertPNG(halfRed, tt.size, tt.format)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.contentType, contentType)
+
+			var img image.Image
+			if contentType == "image/webp" {
+				img, err = webp.Decode(bytes.NewReader(data))
+			} else {
+				img, err = png.Decode(bytes.NewReader(data))
+			}
+			require.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, tt.width, tt.height), img.Bounds())
+
+			_, _, _, leftAlpha := img.At(0, 0).RGBA()
+			_, _, _, rightAlpha := img.At(tt.width-1, 0).RGBA()
+			assert.Equal(t, uint32(0xffff), leftAlpha)
+			assert.Equal(t, uint32(0), rightAlpha)
+		})
+	}
+
+	t.Run("Success - downscaling averages the source pixels", func(t *testing.T) {
+		checkers := encodeTestPNG(t, 2, 2, func(x, y int) color.Color {
+			if (x+y)%2 == 0 {
+				return color.NRGBA{R: 200, A: 255}
+			}
+			return color.NRGBA{B: 100, A: 255}
+		})
+
+		data, _, err := convertPNG(checkers, 1, "")
+
+		require.NoError(t, err)
+		img, err := png.Decode(bytes.NewReader(data))
+		require.NoError(t, err)
+		assert.Equal(t, color.NRGBA{R: 100, B: 50, A: 255}, color.NRGBAModel.Convert(img.At(0, 0)))
+	})
+
+	t.Run("Error - not a PNG", func(t *testing.T) {
+		_, _, err := convertPNG([]byte("GIF89a"), 32, "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSpriteStore(t *testing.T) {
+	store, err := newSpriteStore(t.TempDir())
+	require.NoError(t, err)
+
+	const url = "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/25.png"
+	lastModified := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success - round trip", func(t *testing.T) {
+		img := &domain.SpriteImage{Data: []byte("png"), ContentType: "image/png", ETag: domain.StrongETag([]byte("png")), LastModified: lastModified}
+		require.NoError(t, store.Put(store.key(url, 0, ""), img))
+
+		cached, found := store.Get(store.key(url, 0, ""))
+
+		require.True(t, found)
+		assert.Equal(t, img.Data, cached.Data)
+		assert.Equal(t, img.ContentType, cached.ContentType)
+		assert.Equal(t, img.ETag, cached.ETag)
+		assert.True(t, lastModified.Equal(cached.LastModified))
+	})
+
+	t.Run("Success - each size and format is its own entry", func(t *testing.T) {
+		keys := map[string]bool{
+			store.key(url, 0, ""):                       true,
+			store.key(url, 64, ""):                      true,
+			store.key(url, 64, domain.SpriteFormatWebP): true,
+		}
+		assert.Len(t, keys, 3)
+		assert.Equal(t, store.key(url, 64, ""), store.key(url, 64, domain.SpriteFormatPNG))
+
+		_, found := store.Get(store.key(url, 64, domain.SpriteFormatWebP))
+		assert.False(t, found)
+	})
+
+	t.Run("Success - a stored weak ETag is replaced by a strong one", func(t *testing.T) {
+		key := store.key(url, 32, "")
+		require.NoError(t, store.Put(key, &domain.SpriteImage{Data: []byte("old"), ContentType: "image/png", ETag: `W/"abc"`}))
+
+		cached, found := store.Get(key)
+
+		require.True(t, found)
+		assert.Equal(t, domain.StrongETag([]byte("old")), cached.ETag)
+	})
+
+	t.Run("Error - bytes without metadata are not served", func(t *testing.T) {
+		key := store.key(url, 16, "")
+		require.NoError(t, os.WriteFile(filepath.Join(store.dir, key+".bin"), []byte("partial"), 0o644))
+
+		_, found := store.Get(key)
+
+		assert.False(t, found)
+	})
+}
+
+func TestPokeAPIRepository_GetSpriteImage(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	sprite := encodeTestPNG(t, 96, 96, func(x, y int) color.Color { return color.NRGBA{G: 255, A: 255} })
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("ETag", `W/"upstream"`)
+		switch r.URL.Path {
+		case "/25.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(sprite)
+		case "/25.gif":
+			w.Header().Set("Content-Type", "image/gif")
+			w.Write([]byte("GIF89a"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	repo, err := newPokeAPIRepository(server.URL+"/api/v2", http.DefaultTransport)
+	require.NoError(t, err)
+
+	t.Run("Success - the original gets a strong ETag computed locally", func(t *testing.T) {
+		img, err := repo.GetSpriteImage(server.URL+"/25.png", 0, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, sprite, img.Data)
+		assert.Equal(t, domain.StrongETag(sprite), img.ETag)
+	})
+
+	t.Run("Success - converts to WebP once and serves it from disk", func(t *testing.T) {
+		before := atomic.LoadInt32(&hits)
+		for i := 0; i < 2; i++ {
+			img, err := repo.GetSpriteImage(server.URL+"/25.png", 32, domain.SpriteFormatWebP)
+
+			require.NoError(t, err)
+			assert.Equal(t, "image/webp", img.ContentType)
+			assert.Equal(t, domain.StrongETag(img.Data), img.ETag)
+			decoded, err := webp.Decode(bytes.NewReader(img.Data))
+			require.NoError(t, err)
+			assert.Equal(t, 32, decoded.Bounds().Dx())
+		}
+		assert.Equal(t, before, atomic.LoadInt32(&hits))
+	})
+
+	t.Run("Success - animated GIFs are served as they are", func(t *testing.T) {
+		img, err := repo.GetSpriteImage(server.URL+"/25.gif", 64, domain.SpriteFormatWebP)
+
+		require.NoError(t, err)
+		assert.Equal(t, "image/gif", img.ContentType)
+		assert.Equal(t, []byte("GIF89a"), img.Data)
+	})
+
+	t.Run("Error - not found", func(t *testing.T) {
+		_, err := repo.GetSpriteImage(server.URL+"/missing.png", 0, "")
+
+		assert.Equal(t, domain.ErrSpriteNotFound, err)
+	})
+}
+
+func TestNewPokeAPIRepository_SpriteDirError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+	t.Setenv("SPRITE_CACHE_DIR", filepath.Join(file, "sprites"))
+
+	_, err := newPokeAPIRepository("http://127.0.0.1:1/api/v2", http.DefaultTransport)
+
+	assert.Error(t, err)
+}
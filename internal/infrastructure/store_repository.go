@@ -0,0 +1,228 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+// StoreRepository es el repositorio del almacén local. Además del puerto del
+// dominio da el detalle de los tipos, que ningún caso de uso pide y que solo
+// usa cmd/sync para dejarlos en disco.
+type StoreRepository interface {
+	domain.PokeAPIRepository
+	GetTypeByName(name string) (*domain.TypeDetail, error)
+}
+
+// NewStoreRepository crea un repositorio que consulta primero un almacén local
+// con el formato de api-data y solo va a PokeAPI cuando falta el recurso,
+// guardando cada respuesta nueva en el almacén. requestsPerSecond limita las
+// peticiones a la API real (0 desactiva el límite).
+//
+// Como lo ya descargado nunca se vuelve a pedir, una sincronización
+// interrumpida se reanuda donde se quedó, y el almacén resultante también
+// sirve como POKEAPI_SNAPSHOT.
+func NewStoreRepository(dir string, requestsPerSecond float64) (StoreRepository, error) {
+	root := storeRoot(dir)
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create store: %w", err)
+	}
+
+	baseURL := pokeAPIBaseURL()
+	log.Printf("Using local store %s in front of %s", dir, baseURL)
+
+	// Un pokémon o una especie guardados en el almacén no vuelven a pedirse a
+	// PokeAPI, así que expirarlos de la caché solo obligaría a releer el disco.
+	ttls := cacheTTLsFromEnv()
+	ttls.pokemon, ttls.species = 0, 0
+	repo, err := newPokeAPIRepositoryWithTTLs(baseURL, newStoreTransport(root, baseURL, http.DefaultTransport, requestsPerSecond), ttls)
+	if err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+func storeRoot(dir string) string {
+	return filepath.Join(dir, "data", "api", "v2")
+}
+
+type storeTransport struct {
+	root     string
+	baseURL  string
+	local    *snapshotTransport
+	upstream http.RoundTripper
+	limiter  *rateLimiter
+}
+
+func newStoreTransport(root, baseURL string, upstream http.RoundTripper, requestsPerSecond float64) *storeTransport {
+	t := &storeTransport{
+		root:     root,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		local:    newSnapshotTransport(os.DirFS(root), "."),
+		upstream: upstream,
+	}
+	if requestsPerSecond > 0 {
+		t.limiter = &rateLimiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+	}
+	return t
+}
+
+// rateLimiter espacia las peticiones al menos interval. A diferencia de un
+// time.Ticker no necesita goroutine ni hay que pararlo, así que el
+// repositorio puede descartarse sin más.
+type rateLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+}
+
+func (l *rateLimiter) Wait() {
+	l.mu.Lock()
+	now := time.Now()
+	wait := l.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	l.next = now.Add(wait + l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(wait)
+}
+
+func (t *storeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target := req.URL.Scheme + "://" + req.URL.Host + req.URL.Path
+	// Lo que no es de la API (por ejemplo los sprites) va directo a la red.
+	if !strings.HasPrefix(target, t.baseURL+"/") {
+		return t.upstream.RoundTrip(req)
+	}
+	resourcePath := strings.Trim(strings.TrimPrefix(target, t.baseURL), "/")
+
+	if body, ok := t.fromStore(req, resourcePath); ok {
+		body = bytes.ReplaceAll(body, []byte(`"`+snapshotBaseURL+`/`), []byte(`"`+t.baseURL+`/`))
+		return snapshotResponse(req, http.StatusOK, body), nil
+	}
+
+	if t.limiter != nil {
+		t.limiter.Wait()
+	}
+	resp, err := t.upstream.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.record(resourcePath, req.URL.Query(), body); err != nil {
+		log.Printf("Failed to store %s: %v", req.URL, err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func (t *storeTransport) fromStore(req *http.Request, resourcePath string) ([]byte, bool) {
+	localURL, err := url.Parse(snapshotBaseURL + "/" + resourcePath + "?" + req.URL.RawQuery)
+	if err != nil {
+		return nil, false
+	}
+	localReq := req.Clone(req.Context())
+	localReq.URL = localURL
+
+	resp, err := t.local.RoundTrip(localReq)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	return body, err == nil
+}
+
+// record guarda la respuesta en la ruta que usaría api-data. Los recursos
+// pedidos por nombre se guardan bajo su ID, y de los listados solo se guarda
+// la primera página cuando contiene todos los resultados, que es el índice
+// completo del recurso.
+func (t *storeTransport) record(resourcePath string, query url.Values, body []byte) error {
+	segments := strings.Split(resourcePath, "/")
+
+	if len(segments) == 1 {
+		var list PokeAPIResponseList
+		if err := json.Unmarshal(body, &list); err != nil {
+			return err
+		}
+		if offset, _ := strconv.Atoi(query.Get("offset")); offset > 0 || list.Next != "" {
+			return nil
+		}
+	} else if _, err := strconv.Atoi(segments[1]); err != nil {
+		var resource struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(body, &resource); err != nil || resource.ID == 0 {
+			return err
+		}
+		segments[1] = strconv.Itoa(resource.ID)
+	}
+
+	path := filepath.Join(append([]string{t.root}, append(segments, "index.json")...)...)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	body = bytes.ReplaceAll(body, []byte(`"`+t.baseURL+`/`), []byte(`"/api/v2/`))
+	return writeFileAtomic(path, body)
+}
+
+// WarmCache carga en la caché en memoria todos los pokémon del índice del
+// almacén y sus especies, para que el servidor arranque sin fallos de caché.
+func WarmCache(repo domain.PokeAPIRepository, dir string) error {
+	raw, err := os.ReadFile(filepath.Join(storeRoot(dir), "pokemon", "index.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read store index: %w", err)
+	}
+
+	var index PokeAPIResponseList
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return fmt.Errorf("failed to decode store index: %w", err)
+	}
+
+	start := time.Now()
+	species := make(map[int]bool)
+	for _, result := range index.Results {
+		id, ok := domain.ResourceID(result.URL)
+		if !ok {
+			continue
+		}
+
+		pokemon, err := repo.GetPokemonByID(id)
+		if err != nil {
+			return fmt.Errorf("failed to warm pokemon %d: %w", id, err)
+		}
+
+		speciesID, ok := domain.ResourceID(pokemon.Species.URL)
+		if !ok || species[speciesID] {
+			continue
+		}
+		if _, err := repo.GetPokemonSpecies(speciesID); err != nil {
+			return fmt.Errorf("failed to warm species %d: %w", speciesID, err)
+		}
+		species[speciesID] = true
+	}
+
+	log.Printf("Cache warmed with %d pokemon and %d species in %v", len(index.Results), len(species), time.Since(start))
+	return nil
+}
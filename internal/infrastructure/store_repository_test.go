@@ -0,0 +1,139 @@
+package infrastructure
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFakePokeAPI(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		base := server.URL + "/api/v2"
+		switch r.URL.Path {
+		case "/api/v2/pokemon":
+			next := "null"
+			if r.URL.Query().Get("limit") == "1" {
+				next = fmt.Sprintf("%q", base+"/pokemon?offset=1&limit=1")
+			}
+			fmt.Fprintf(w, `{"count":1,"next":%s,"previous":null,"results":[{"name":"pikachu","url":"%s/pokemon/25/"}]}`, next, base)
+		case "/api/v2/pokemon/25", "/api/v2/pokemon/pikachu":
+			fmt.Fprintf(w, `{"id":25,"name":"pikachu","species":{"name":"pikachu","url":"%s/pokemon-species/25/"}}`, base)
+		case "/api/v2/pokemon-species/25":
+			fmt.Fprint(w, `{"id":25,"name":"pikachu"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestStoreRepository(t *testing.T) {
+	t.Setenv("SPRITE_CACHE_DIR", t.TempDir())
+	var hits int32
+	server := newFakePokeAPI(t, &hits)
+	t.Setenv("POKEAPI_BASE_URL", server.URL+"/api/v2")
+	dir := t.TempDir()
+
+	repo, err := NewStoreRepository(dir, 0)
+	require.NoError(t, err)
+
+	t.Run("Success - stores resources fetched by name under their ID", func(t *testing.T) {
+		pokemon, err := repo.GetPokemonByName("pikachu")
+
+		require.NoError(t, err)
+		assert.Equal(t, 25, pokemon.ID)
+		raw, err := os.ReadFile(filepath.Join(dir, "data/api/v2/pokemon/25/index.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(raw), `"/api/v2/pokemon-species/25/"`)
+	})
+
+	t.Run("Success - only complete lists are stored as the index", func(t *testing.T) {
+		_, err := repo.GetPokemonAll(domain.PokemonFilter{Limit: 1})
+		require.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, "data/api/v2/pokemon/index.json"))
+
+		_, err = repo.GetPokemonAll(domain.PokemonFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "data/api/v2/pokemon/index.json"))
+	})
+
+	t.Run("Success - a complete summary listing is stored as the index", func(t *testing.T) {
+		summaryDir := t.TempDir()
+		summaryRepo, err := NewStoreRepository(summaryDir, 0)
+		require.NoError(t, err)
+
+		_, err = summaryRepo.GetPokemonSummaries(domain.PokemonFilter{Limit: 10})
+
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(summaryDir, "data/api/v2/pokemon/index.json"))
+		assert.NoFileExists(t, filepath.Join(summaryDir, "data/api/v2/pokemon/25/index.json"))
+	})
+
+	t.Run("Success - a new repository resumes from the store", func(t *testing.T) {
+		_, err := repo.GetPokemonSpecies(25)
+		require.NoError(t, err)
+		before := atomic.LoadInt32(&hits)
+
+		resumed, err := NewStoreRepository(dir, 0)
+		require.NoError(t, err)
+		require.NoError(t, WarmCache(resumed, dir))
+
+		pokemon, err := resumed.GetPokemonByName("pikachu")
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/api/v2/pokemon-species/25/", pokemon.Species.URL)
+		assert.Equal(t, before, atomic.LoadInt32(&hits))
+	})
+
+	t.Run("Success - warmed entries do not expire", func(t *testing.T) {
+		t.Setenv("CACHE_TTL", "1ms")
+		warmed, err := NewStoreRepository(dir, 0)
+		require.NoError(t, err)
+		require.NoError(t, WarmCache(warmed, dir))
+
+		time.Sleep(5 * time.Millisecond)
+
+		caches := warmed.(*pokeAPIRepository).caches
+		_, found := caches.pokemonByID.Get(25)
+		assert.True(t, found)
+		_, found = caches.species.Get(25)
+		assert.True(t, found)
+	})
+
+	t.Run("Error - missing resource", func(t *testing.T) {
+		_, err := repo.GetPokemonByID(151)
+
+		assert.Equal(t, domain.ErrPokemonNotFound, err)
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := &rateLimiter{interval: 20 * time.Millisecond}
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limiter.Wait()
+		}()
+	}
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
@@ -0,0 +1,172 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sort"
+	"sync"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+// teamRepository guarda los equipos sin expiración sobre un CacheBackend:
+// en memoria se pierden al reiniciar, pero con el backend de disco o Redis
+// sobreviven a los despliegues y, con Redis, se comparten entre instancias.
+// Cada usuario tiene un índice con los IDs de sus equipos.
+type teamRepository struct {
+	teams  *Cache[string, domain.Team]
+	byUser *Cache[string, []string]
+	// sets, si el backend lo implementa, guarda el índice como un conjunto
+	// que se actualiza de forma atómica; si es nil se usa byUser.
+	sets SetBackend
+	// mu serializa las actualizaciones del índice dentro de la instancia.
+	mu sync.Mutex
+}
+
+// SetBackend lo implementan los backends compartidos entre instancias que
+// pueden añadir y quitar miembros de un conjunto en una sola operación, como
+// Redis con SADD y SREM. Sin él, el índice de cada usuario se reescribe
+// entero, y dos instancias que crean equipos del mismo usuario a la vez
+// perderían uno de los IDs.
+type SetBackend interface {
+	AddMember(key, member string) error
+	RemoveMember(key, member string) error
+	Members(key string) ([]string, error)
+}
+
+// teamSetPrefix es el índice por usuario cuando el backend es un SetBackend.
+// Usa otra clave que byUser porque en Redis un conjunto y un string no
+// pueden compartirla.
+const teamSetPrefix = "team:members:"
+
+// NewTeamRepository guarda los equipos en memoria del proceso.
+func NewTeamRepository() domain.TeamRepository {
+	return newTeamRepository(NewMemoryCache())
+}
+
+// NewTeamRepositoryFromEnv elige dónde persistir los equipos con
+// TEAM_BACKEND ("memory", "disk" o "redis"), igual que CACHE_BACKEND. Con
+// "disk" los archivos van a TEAM_DIR.
+func NewTeamRepositoryFromEnv() (domain.TeamRepository, error) {
+	backend, _, err := openBackend(os.Getenv("TEAM_BACKEND"), os.Getenv("TEAM_DIR"), "teams")
+	if err != nil {
+		return nil, err
+	}
+	return newTeamRepository(backend), nil
+}
+
+func newTeamRepository(backend CacheBackend) *teamRepository {
+	sets, _ := backend.(SetBackend)
+	return &teamRepository{
+		teams:  NewCache[string, domain.Team](backend, "team:id:", 0),
+		byUser: NewCache[string, []string](backend, "team:user:", 0),
+		sets:   sets,
+	}
+}
+
+func (r *teamRepository) Create(team *domain.Team) error {
+	id, err := newTeamID()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	team.ID = id
+	r.teams.Set(id, *team)
+
+	if r.sets != nil {
+		if err := r.sets.AddMember(teamSetPrefix+team.UserID, id); err != nil {
+			r.teams.Delete(id)
+			return err
+		}
+		return nil
+	}
+
+	ids, _ := r.byUser.Get(team.UserID)
+	r.byUser.Set(team.UserID, append(ids, id))
+	return nil
+}
+
+func (r *teamRepository) GetByID(id string) (*domain.Team, error) {
+	team, exists := r.teams.Get(id)
+	if !exists {
+		return nil, domain.ErrTeamNotFound
+	}
+	return &team, nil
+}
+
+func (r *teamRepository) ListByUser(userID string) ([]domain.Team, error) {
+	ids, err := r.teamIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := []domain.Team{}
+	for _, id := range ids {
+		if team, exists := r.teams.Get(id); exists {
+			teams = append(teams, team)
+		}
+	}
+
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].CreatedAt.Before(teams[j].CreatedAt)
+	})
+	return teams, nil
+}
+
+func (r *teamRepository) Update(team *domain.Team) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.teams.Get(team.ID); !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	r.teams.Set(team.ID, *team)
+	return nil
+}
+
+func (r *teamRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	team, exists := r.teams.Get(id)
+	if !exists {
+		return domain.ErrTeamNotFound
+	}
+
+	r.teams.Delete(id)
+
+	if r.sets != nil {
+		return r.sets.RemoveMember(teamSetPrefix+team.UserID, id)
+	}
+
+	ids, _ := r.byUser.Get(team.UserID)
+	remaining := ids[:0]
+	for _, other := range ids {
+		if other != id {
+			remaining = append(remaining, other)
+		}
+	}
+	r.byUser.Set(team.UserID, remaining)
+	return nil
+}
+
+func (r *teamRepository) teamIDs(userID string) ([]string, error) {
+	if r.sets != nil {
+		return r.sets.Members(teamSetPrefix + userID)
+	}
+	ids, _ := r.byUser.Get(userID)
+	return ids, nil
+}
+
+func newTeamID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
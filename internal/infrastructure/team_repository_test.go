@@ -0,0 +1,124 @@
+package infrastructure
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamRepository(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TEAM_BACKEND", "disk")
+	t.Setenv("TEAM_DIR", dir)
+
+	repo, err := NewTeamRepositoryFromEnv()
+	require.NoError(t, err)
+
+	first := &domain.Team{UserID: "ash", Name: "kanto", CreatedAt: time.Now(), Members: []domain.TeamMember{{PokemonID: 25, Moves: []string{"thunderbolt"}}}}
+	second := &domain.Team{UserID: "ash", Name: "johto", CreatedAt: first.CreatedAt.Add(time.Second), Members: []domain.TeamMember{{PokemonID: 155}}}
+	other := &domain.Team{UserID: "misty", Name: "water", CreatedAt: time.Now(), Members: []domain.TeamMember{{PokemonID: 121}}}
+	require.NoError(t, repo.Create(first))
+	require.NoError(t, repo.Create(second))
+	require.NoError(t, repo.Create(other))
+
+	t.Run("Success - teams survive a new instance", func(t *testing.T) {
+		reopened, err := NewTeamRepositoryFromEnv()
+		require.NoError(t, err)
+
+		teams, err := reopened.ListByUser("ash")
+		require.NoError(t, err)
+		require.Len(t, teams, 2)
+		assert.Equal(t, "kanto", teams[0].Name)
+		assert.Equal(t, "johto", teams[1].Name)
+		assert.Equal(t, []string{"thunderbolt"}, teams[0].Members[0].Moves)
+	})
+
+	t.Run("Success - reads return copies", func(t *testing.T) {
+		team, err := repo.GetByID(first.ID)
+		require.NoError(t, err)
+		team.Members[0].Moves[0] = "surf"
+
+		again, err := repo.GetByID(first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "thunderbolt", again.Members[0].Moves[0])
+	})
+
+	t.Run("Success - update and delete", func(t *testing.T) {
+		second.Name = "johto-2"
+		require.NoError(t, repo.Update(second))
+		require.NoError(t, repo.Delete(first.ID))
+
+		teams, err := repo.ListByUser("ash")
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, "johto-2", teams[0].Name)
+
+		_, err = repo.GetByID(first.ID)
+		assert.Equal(t, domain.ErrTeamNotFound, err)
+		assert.Equal(t, domain.ErrTeamNotFound, repo.Delete(first.ID))
+		assert.Equal(t, domain.ErrTeamNotFound, repo.Update(first))
+	})
+
+	t.Run("Error - unknown backend", func(t *testing.T) {
+		t.Setenv("TEAM_BACKEND", "s3")
+		_, err := NewTeamRepositoryFromEnv()
+		assert.Error(t, err)
+	})
+}
+
+func TestTeamRepository_RedisIndex(t *testing.T) {
+	addr, server := startFakeRedis(t)
+	t.Setenv("TEAM_BACKEND", "redis")
+	t.Setenv("REDIS_ADDR", addr)
+
+	// Dos instancias que crean equipos del mismo usuario a la vez.
+	first, err := NewTeamRepositoryFromEnv()
+	require.NoError(t, err)
+	second, err := NewTeamRepositoryFromEnv()
+	require.NoError(t, err)
+
+	const perInstance = 20
+	var wg sync.WaitGroup
+	for _, repo := range []domain.TeamRepository{first, second} {
+		for i := 0; i < perInstance; i++ {
+			wg.Add(1)
+			go func(repo domain.TeamRepository, i int) {
+				defer wg.Done()
+				team := &domain.Team{UserID: "ash", Name: fmt.Sprintf("team-%d", i), CreatedAt: time.Now()}
+				assert.NoError(t, repo.Create(team))
+			}(repo, i)
+		}
+	}
+	wg.Wait()
+
+	t.Run("Success - no team is lost from the index", func(t *testing.T) {
+		teams, err := first.ListByUser("ash")
+		require.NoError(t, err)
+		assert.Len(t, teams, 2*perInstance)
+
+		server.mu.Lock()
+		assert.Len(t, server.sets["team:members:ash"], 2*perInstance)
+		server.mu.Unlock()
+		_, legacy := server.raw("team:user:ash")
+		assert.False(t, legacy)
+	})
+
+	t.Run("Success - delete removes the team from the index", func(t *testing.T) {
+		teams, err := second.ListByUser("ash")
+		require.NoError(t, err)
+		require.NoError(t, second.Delete(teams[0].ID))
+
+		remaining, err := first.ListByUser("ash")
+		require.NoError(t, err)
+		assert.Len(t, remaining, 2*perInstance-1)
+		for _, team := range remaining {
+			assert.NotEqual(t, teams[0].ID, team.ID)
+		}
+	})
+}
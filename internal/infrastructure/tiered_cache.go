@@ -0,0 +1,133 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	// invalidateAll es el mensaje de invalidación que vacía el L1 completo.
+	invalidateAll = "*"
+
+	// l1FillTTL es el TTL pedido al copiar al L1 un valor leído del L2; el
+	// L1 lo recorta a su propio TTL.
+	l1FillTTL = 24 * time.Hour
+)
+
+// CacheInvalidator difunde entre instancias las claves escritas o borradas de
+// la caché compartida para que cada una las quite de su L1. Subscribe
+// devuelve la función que cancela la suscripción.
+type CacheInvalidator interface {
+	Publish(message string)
+	Subscribe(handle func(message string)) (func(), error)
+}
+
+// TieredCache consulta primero un L1 local y pequeño y después un L2
+// compartido. Las escrituras van a ambos niveles, cada uno con su TTL, y tanto
+// las escrituras como los borrados se difunden a las demás instancias a
+// través del invalidator. Cada mensaje lleva el ID de la instancia que lo
+// envía para que esta no descarte su propio L1.
+type TieredCache struct {
+	l1          CacheBackend
+	l2          CacheBackend
+	invalidator CacheInvalidator
+	instanceID  string
+	unsubscribe func()
+}
+
+// NewTieredCache compone los dos niveles. invalidator puede ser nil cuando no
+// hay otras instancias que avisar.
+func NewTieredCache(l1, l2 CacheBackend, invalidator CacheInvalidator) (*TieredCache, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate cache instance ID: %w", err)
+	}
+	cache := &TieredCache{l1: l1, l2: l2, invalidator: invalidator, instanceID: hex.EncodeToString(id)}
+
+	if invalidator != nil {
+		unsubscribe, err := invalidator.Subscribe(cache.evict)
+		if err != nil {
+			return nil, err
+		}
+		cache.unsubscribe = unsubscribe
+	}
+	return cache, nil
+}
+
+// Close deja de recibir invalidaciones de las demás instancias.
+func (c *TieredCache) Close() {
+	if c.unsubscribe != nil {
+		c.unsubscribe()
+	}
+}
+
+func (c *TieredCache) Get(key string) ([]byte, bool) {
+	if value, found := c.l1.Get(key); found {
+		return value, true
+	}
+
+	// El TTL restante del L2 no se conoce, así que el L1 aplica el suyo.
+	value, found := c.l2.Get(key)
+	if found {
+		c.l1.Set(key, value, l1FillTTL)
+	}
+	return value, found
+}
+
+// Set avisa a las demás instancias para que no sigan sirviendo desde su L1
+// el valor anterior de la clave.
+func (c *TieredCache) Set(key string, value []byte, ttl time.Duration) {
+	c.l1.Set(key, value, ttl)
+	c.l2.Set(key, value, ttl)
+	c.publish(key)
+}
+
+func (c *TieredCache) Delete(key string) {
+	c.l1.Delete(key)
+	c.l2.Delete(key)
+	c.publish(key)
+}
+
+func (c *TieredCache) Clear() {
+	c.l1.Clear()
+	c.l2.Clear()
+	c.publish(invalidateAll)
+}
+
+// Size devuelve las entradas del L2, que es el que contiene todas.
+func (c *TieredCache) Size() int {
+	return c.l2.Size()
+}
+
+// publish envía "<instancia> <clave>"; las claves de la caché no contienen
+// espacios.
+func (c *TieredCache) publish(key string) {
+	if c.invalidator != nil {
+		c.invalidator.Publish(c.instanceID + " " + key)
+	}
+}
+
+// evict aplica en el L1 las invalidaciones de las demás instancias. Los
+// mensajes sin instancia, como el que emite el invalidator al reconectarse,
+// se aplican siempre.
+func (c *TieredCache) evict(message string) {
+	key := message
+	if origin, rest, tagged := strings.Cut(message, " "); tagged {
+		if origin == c.instanceID {
+			return
+		}
+		key = rest
+	}
+
+	if key == invalidateAll {
+		log.Printf("Cache invalidation received: clearing L1")
+		c.l1.Clear()
+		return
+	}
+	log.Printf("Cache invalidation received for key: %s", key)
+	c.l1.Delete(key)
+}
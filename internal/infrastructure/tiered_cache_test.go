@@ -0,0 +1,138 @@
+package infrastructure
+
+import (
+	"testing"
+	"time"
+
+	"reto-pokemon-api/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache(t *testing.T) {
+	t.Run("Success - evicts the least recently used entry", func(t *testing.T) {
+		cache := NewLRUCache(2, time.Hour)
+		cache.Set("pokemon:id:1", []byte("1"), time.Hour)
+		cache.Set("pokemon:id:2", []byte("2"), time.Hour)
+		cache.Get("pokemon:id:1")
+		cache.Set("pokemon:id:3", []byte("3"), time.Hour)
+
+		_, found := cache.Get("pokemon:id:2")
+		assert.False(t, found)
+		_, found = cache.Get("pokemon:id:1")
+		assert.True(t, found)
+		assert.Equal(t, 2, cache.Size())
+	})
+
+	t.Run("Success - its TTL caps the entry TTL", func(t *testing.T) {
+		cache := NewLRUCache(2, -time.Second)
+		cache.Set("pokemon:id:1", []byte("1"), time.Hour)
+
+		_, found := cache.Get("pokemon:id:1")
+		assert.False(t, found)
+	})
+}
+
+func TestTieredCache(t *testing.T) {
+	addr, _ := startFakeRedis(t)
+
+	newInstance := func() (*TieredCache, *LRUCache) {
+		shared, err := NewRedisCache(addr, "", 0)
+		require.NoError(t, err)
+		l1 := NewLRUCache(10, time.Minute)
+		cache, err := NewTieredCache(l1, shared, shared)
+		require.NoError(t, err)
+		return cache, l1
+	}
+	a, _ := newInstance()
+	b, bL1 := newInstance()
+
+	t.Run("Success - writes go through to the shared tier", func(t *testing.T) {
+		NewCache[int, *domain.Pokemon](a, "pokemon:id:", time.Hour).Set(25, &domain.Pokemon{ID: 25, Name: "pikachu"})
+
+		pokemon, found := NewCache[int, *domain.Pokemon](b, "pokemon:id:", time.Hour).Get(25)
+		require.True(t, found)
+		assert.Equal(t, "pikachu", pokemon.Name)
+		_, found = bL1.Get("pokemon:id:25")
+		assert.True(t, found)
+	})
+
+	t.Run("Success - deletes clear peers' L1", func(t *testing.T) {
+		a.Delete("pokemon:id:25")
+
+		assert.Eventually(t, func() bool {
+			_, found := bL1.Get("pokemon:id:25")
+			return !found
+		}, time.Second, 10*time.Millisecond)
+		_, found := b.Get("pokemon:id:25")
+		assert.False(t, found)
+	})
+
+	t.Run("Success - clear empties peers' L1", func(t *testing.T) {
+		bL1.Set("pokemon:id:1", []byte(`{"id":1}`), time.Hour)
+		a.Clear()
+
+		assert.Eventually(t, func() bool {
+			return bL1.Size() == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Success - writes clear peers' stale L1 but not their own", func(t *testing.T) {
+		c, cL1 := newInstance()
+		defer c.Close()
+		cL1.Set("move:name:tackle", []byte(`{"power":35}`), time.Hour)
+
+		a.Set("move:name:tackle", []byte(`{"power":40}`), time.Hour)
+
+		assert.Eventually(t, func() bool {
+			value, _ := c.Get("move:name:tackle")
+			return string(value) == `{"power":40}`
+		}, time.Second, 10*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		value, found := a.l1.Get("move:name:tackle")
+		assert.True(t, found)
+		assert.Equal(t, `{"power":40}`, string(value))
+	})
+
+	t.Run("Success - closed instances stop receiving invalidations", func(t *testing.T) {
+		c, cL1 := newInstance()
+		cL1.Set("pokemon:id:7", []byte(`{"id":7}`), time.Hour)
+		c.Close()
+		c.Close()
+
+		a.Delete("pokemon:id:7")
+		time.Sleep(50 * time.Millisecond)
+
+		_, found := cL1.Get("pokemon:id:7")
+		assert.True(t, found)
+	})
+}
+
+func TestTieredCache_Evict(t *testing.T) {
+	l1 := NewLRUCache(10, time.Minute)
+	cache, err := NewTieredCache(l1, NewMemoryCache(), nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		message string
+		evicted bool
+	}{
+		{name: "Success - own messages are skipped", message: cache.instanceID + " pokemon:id:25", evicted: false},
+		{name: "Success - peers' messages evict the key", message: "0123456789abcdef pokemon:id:25", evicted: true},
+		{name: "Success - untagged messages evict the key", message: "pokemon:id:25", evicted: true},
+		{name: "Success - untagged clear empties the L1", message: invalidateAll, evicted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l1.Set("pokemon:id:25", []byte(`{"id":25}`), time.Minute)
+
+			cache.evict(tt.message)
+
+			_, found := l1.Get("pokemon:id:25")
+			assert.Equal(t, tt.evicted, !found)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package infrastructure
+
+import (
+	"net/http"
+
+	"reto-pokemon-api/internal/domain"
+)
+
+// upstreamValidators son las cabeceras con las que PokeAPI permite revalidar
+// un recurso sin volver a descargarlo.
+type upstreamValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+func validatorsFrom(header http.Header) upstreamValidators {
+	return upstreamValidators{
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+	}
+}
+
+func (v upstreamValidators) empty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+func (v upstreamValidators) apply(req *http.Request) {
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// upstreamPokemon es la última respuesta válida de PokeAPI para una URL de
+// pokémon, ya mapeada. Vive más que la caché normal (CACHE_STALE_TTL) para
+// poder revalidarla cuando esa expira.
+type upstreamPokemon struct {
+	Validators upstreamValidators   `json:"validators"`
+	Pokemon    *domain.Pokemon      `json:"pokemon"`
+	Moves      []domain.PokemonMove `json:"moves"`
+}